@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+	"review-service/internal/data"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/core/bulk"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/operationtype"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/versiontype"
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// 评论索引的mapping定义，修改后需要执行reindex生成新版本索引
+//
+//go:embed review_mapping.json
+var reviewMapping string
+
+var (
+	flagconf  string
+	action    string
+	version   int
+	batchSize int
+)
+
+// 版本化索引名：review_v{n}
+var indexVersionRe = regexp.MustCompile("^" + data.ReviewIndexAlias + `_v(\d+)$`)
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.StringVar(&action, "action", "reindex", "create: 创建新版本索引; reindex: 从MySQL重建新版本索引并切换别名; alias: 将别名切换到指定版本")
+	flag.IntVar(&version, "version", 0, "索引版本号，为0时自动取下一个版本")
+	flag.IntVar(&batchSize, "batch", 500, "reindex每批写入的文档数")
+}
+
+type indexer struct {
+	es    *elasticsearch.TypedClient
+	query *query.Query
+}
+
+func main() {
+	flag.Parse()
+
+	c := config.New(
+		config.WithSource(
+			file.NewSource(flagconf),
+		),
+	)
+	defer c.Close()
+
+	if err := c.Load(); err != nil {
+		panic(err)
+	}
+
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		panic(err)
+	}
+
+	esClient, err := elasticsearch.NewTypedClient(elasticsearch.Config{
+		Addresses: bc.Elasticsearch.Addresses,
+	})
+	if err != nil {
+		panic(err)
+	}
+	db, err := gorm.Open(mysql.Open(bc.Data.Database.Source), &gorm.Config{})
+	if err != nil {
+		panic(err)
+	}
+	idx := &indexer{es: esClient, query: query.Use(db)}
+
+	ctx := context.Background()
+	switch action {
+	case "create":
+		err = idx.create(ctx)
+	case "reindex":
+		err = idx.reindex(ctx)
+	case "alias":
+		if version <= 0 {
+			err = errors.New("切换别名需要指定-version")
+			break
+		}
+		err = idx.switchAlias(ctx, indexName(version))
+	default:
+		err = fmt.Errorf("未知的action: %s", action)
+	}
+	if err != nil {
+		log.Fatalf("执行%s失败: %v", action, err)
+	}
+}
+
+func indexName(v int) string {
+	return fmt.Sprintf("%s_v%d", data.ReviewIndexAlias, v)
+}
+
+// create 创建新版本索引，别名不存在时直接指向新索引
+func (i *indexer) create(ctx context.Context) error {
+	name, err := i.createIndex(ctx)
+	if err != nil {
+		return err
+	}
+	aliasExists, err := i.es.Indices.ExistsAlias(data.ReviewIndexAlias).IsSuccess(ctx)
+	if err != nil {
+		return err
+	}
+	if aliasExists {
+		log.Infof("索引%s创建完成，别名%s未切换", name, data.ReviewIndexAlias)
+		return nil
+	}
+	return i.switchAlias(ctx, name)
+}
+
+// reindex 从MySQL全量重建新版本索引后切换别名。
+// 重建期间线上仍通过别名写旧索引，重建前记录同步事件位点，
+// 全量写入后和切换别名后各回放一次位点之后的变更，保证新索引不丢更新。
+// 与线上同步任务一致，以同步事件ID作为external_gte版本号，全量写入的快照不早于位点，使用位点作为版本号
+func (i *indexer) reindex(ctx context.Context) error {
+	mark, err := i.lastOutboxID(ctx)
+	if err != nil {
+		return err
+	}
+	name, err := i.createIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 全量写入期间关闭刷新，加快写入
+	if err := i.setRefreshInterval(ctx, name, "-1"); err != nil {
+		return err
+	}
+	total, err := i.loadAll(ctx, name, mark)
+	if err != nil {
+		return err
+	}
+	log.Infof("全量写入%s完成，共%d条", name, total)
+	if err := i.setRefreshInterval(ctx, name, "1s"); err != nil {
+		return err
+	}
+
+	if mark, err = i.replay(ctx, name, mark); err != nil {
+		return err
+	}
+	if _, err := i.es.Indices.Refresh().Index(name).Do(ctx); err != nil {
+		return err
+	}
+	if err := i.switchAlias(ctx, name); err != nil {
+		return err
+	}
+	// 回放切换别名前写入旧索引的变更
+	_, err = i.replay(ctx, name, mark)
+	return err
+}
+
+func (i *indexer) setRefreshInterval(ctx context.Context, name, interval string) error {
+	body := fmt.Sprintf(`{"index":{"refresh_interval":%q}}`, interval)
+	_, err := i.es.Indices.PutSettings().Indices(name).Raw(strings.NewReader(body)).Do(ctx)
+	return err
+}
+
+// createIndex 按mapping创建新版本索引
+func (i *indexer) createIndex(ctx context.Context) (string, error) {
+	v := version
+	if v <= 0 {
+		latest, err := i.latestVersion(ctx)
+		if err != nil {
+			return "", err
+		}
+		v = latest + 1
+	}
+	name := indexName(v)
+	if _, err := i.es.Indices.Create(name).Raw(strings.NewReader(reviewMapping)).Do(ctx); err != nil {
+		return "", err
+	}
+	log.Infof("创建索引%s", name)
+	return name, nil
+}
+
+// latestVersion 获取已存在的最大索引版本号，不存在时返回0
+func (i *indexer) latestVersion(ctx context.Context) (int, error) {
+	indices, err := i.es.Indices.Get(data.ReviewIndexAlias + "_v*").Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	latest := 0
+	for name := range indices {
+		m := indexVersionRe.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+		if v, _ := strconv.Atoi(m[1]); v > latest {
+			latest = v
+		}
+	}
+	return latest, nil
+}
+
+// switchAlias 原子地将别名切换到指定索引。
+// 旧版本中review是按动态mapping自动创建的实体索引，切换时一并删除，数据已由reindex重建。
+func (i *indexer) switchAlias(ctx context.Context, name string) error {
+	alias := data.ReviewIndexAlias
+	actions := make([]types.IndicesActionVariant, 0, 2)
+
+	aliasExists, err := i.es.Indices.ExistsAlias(alias).IsSuccess(ctx)
+	if err != nil {
+		return err
+	}
+	if aliasExists {
+		current, err := i.es.Indices.GetAlias().Name(alias).Do(ctx)
+		if err != nil {
+			return err
+		}
+		for index := range current {
+			if index == name {
+				continue
+			}
+			actions = append(actions, &types.IndicesAction{Remove: &types.RemoveAction{Index: &index, Alias: &alias}})
+		}
+	} else {
+		concrete, err := i.es.Indices.Exists(alias).IsSuccess(ctx)
+		if err != nil {
+			return err
+		}
+		if concrete {
+			if action == "create" {
+				return fmt.Errorf("已存在名为%s的实体索引，请使用-action=reindex迁移数据", alias)
+			}
+			actions = append(actions, &types.IndicesAction{RemoveIndex: &types.RemoveIndexAction{Index: &alias}})
+		}
+	}
+	isWriteIndex := true
+	actions = append(actions, &types.IndicesAction{Add: &types.AddAction{Index: &name, Alias: &alias, IsWriteIndex: &isWriteIndex}})
+
+	if _, err := i.es.Indices.UpdateAliases().Actions(actions...).Do(ctx); err != nil {
+		return err
+	}
+	log.Infof("别名%s已切换到%s", alias, name)
+	return nil
+}
+
+// loadAll 按主键分批从MySQL读取未删除的评论写入索引
+func (i *indexer) loadAll(ctx context.Context, name string, version int64) (int, error) {
+	reviewInfo := i.query.ReviewInfo
+	var lastID int64
+	total := 0
+	for {
+		reviews, err := reviewInfo.WithContext(ctx).
+			Where(reviewInfo.ID.Gt(lastID), reviewInfo.DeleteAt.IsNull()).
+			Order(reviewInfo.ID).
+			Limit(batchSize).
+			Find()
+		if err != nil {
+			return total, err
+		}
+		if len(reviews) == 0 {
+			return total, nil
+		}
+		reviewIDs := make([]int64, len(reviews))
+		for j, review := range reviews {
+			reviewIDs[j] = review.ReviewID
+		}
+		appends, err := i.approvedAppends(ctx, reviewIDs)
+		if err != nil {
+			return total, err
+		}
+		req := i.es.Bulk().Index(name)
+		for _, review := range reviews {
+			if err := indexOp(req, review, appends[review.ReviewID], version); err != nil {
+				return total, err
+			}
+		}
+		if err := doBulk(ctx, req); err != nil {
+			return total, err
+		}
+		total += len(reviews)
+		lastID = reviews[len(reviews)-1].ID
+	}
+}
+
+// lastOutboxID 获取当前最大的同步事件ID
+func (i *indexer) lastOutboxID(ctx context.Context) (int64, error) {
+	outbox := i.query.ReviewEsOutbox
+	event, err := outbox.WithContext(ctx).Order(outbox.ID.Desc()).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return event.ID, nil
+}
+
+// replay 将位点之后有变更的评论按MySQL最新数据写入索引，返回新的位点
+func (i *indexer) replay(ctx context.Context, name string, mark int64) (int64, error) {
+	outbox := i.query.ReviewEsOutbox
+	reviewInfo := i.query.ReviewInfo
+	for {
+		events, err := outbox.WithContext(ctx).
+			Where(outbox.ID.Gt(mark)).
+			Order(outbox.ID).
+			Limit(batchSize).
+			Find()
+		if err != nil {
+			return mark, err
+		}
+		if len(events) == 0 {
+			return mark, nil
+		}
+		// 同一条评论取最大的事件ID作为版本号
+		versions := make(map[int64]int64, len(events))
+		reviewIDs := make([]int64, 0, len(events))
+		for _, event := range events {
+			if _, ok := versions[event.ReviewID]; !ok {
+				reviewIDs = append(reviewIDs, event.ReviewID)
+			}
+			versions[event.ReviewID] = max(versions[event.ReviewID], event.ID)
+		}
+		reviews, err := reviewInfo.WithContext(ctx).Where(reviewInfo.ReviewID.In(reviewIDs...)).Find()
+		if err != nil {
+			return mark, err
+		}
+		found := make(map[int64]*model.ReviewInfo, len(reviews))
+		for _, review := range reviews {
+			found[review.ReviewID] = review
+		}
+		appends, err := i.approvedAppends(ctx, reviewIDs)
+		if err != nil {
+			return mark, err
+		}
+
+		req := i.es.Bulk().Index(name)
+		for _, reviewID := range reviewIDs {
+			review, ok := found[reviewID]
+			if !ok || review.DeleteAt != nil {
+				id := strconv.FormatInt(reviewID, 10)
+				v := versions[reviewID]
+				if err := req.DeleteOp(types.DeleteOperation{Id_: &id, Version: &v, VersionType: &versiontype.Externalgte}); err != nil {
+					return mark, err
+				}
+				continue
+			}
+			if err := indexOp(req, review, appends[reviewID], versions[reviewID]); err != nil {
+				return mark, err
+			}
+		}
+		if err := doBulk(ctx, req); err != nil {
+			return mark, err
+		}
+		mark = events[len(events)-1].ID
+		log.Infof("回放同步事件至%d", mark)
+	}
+}
+
+// approvedAppends 批量查询审核通过的追评，按评论ID索引
+func (i *indexer) approvedAppends(ctx context.Context, reviewIDs []int64) (map[int64]*model.ReviewAppendInfo, error) {
+	reviewAppend := i.query.ReviewAppendInfo
+	appends, err := reviewAppend.WithContext(ctx).
+		Where(
+			reviewAppend.ReviewID.In(reviewIDs...),
+			reviewAppend.Status.Eq(biz.ReviewStatusApproved),
+			reviewAppend.DeleteAt.IsNull(),
+		).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]*model.ReviewAppendInfo, len(appends))
+	for _, a := range appends {
+		result[a.ReviewID] = a
+	}
+	return result, nil
+}
+
+func indexOp(req *bulk.Bulk, review *model.ReviewInfo, reviewAppend *model.ReviewAppendInfo, version int64) error {
+	id := strconv.FormatInt(review.ReviewID, 10)
+	op := types.IndexOperation{Id_: &id, Version: &version, VersionType: &versiontype.Externalgte}
+	return req.IndexOp(op, data.ToReviewDoc(review, reviewAppend))
+}
+
+// doBulk 执行批量请求，删除不存在的文档、已有更新版本的文档不视为失败
+func doBulk(ctx context.Context, req *bulk.Bulk) error {
+	res, err := req.Do(ctx)
+	if err != nil {
+		return err
+	}
+	if !res.Errors {
+		return nil
+	}
+	for _, item := range res.Items {
+		for op, result := range item {
+			if result.Error == nil || result.Status == http.StatusConflict ||
+				(op == operationtype.Delete && result.Status == http.StatusNotFound) {
+				continue
+			}
+			reason := ""
+			if result.Error.Reason != nil {
+				reason = *result.Error.Reason
+			}
+			return fmt.Errorf("%s文档%s失败: %s", op, *result.Id_, reason)
+		}
+	}
+	return nil
+}
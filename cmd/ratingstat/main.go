@@ -0,0 +1,207 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	flagconf string
+	fix      bool
+)
+
+func init() {
+	flag.StringVar(&flagconf, "conf", "../../configs", "config path, eg: -conf config.yaml")
+	flag.BoolVar(&fix, "fix", false, "是否修复有偏差的统计，默认只输出偏差")
+}
+
+// statRow 评分统计，Key为店铺id或spu id
+type statRow struct {
+	Key             int64
+	ReviewCount     int64
+	ScoreSum        int64
+	ServiceScoreSum int64
+	ExpressScoreSum int64
+	Score1Count     int64
+	Score2Count     int64
+	Score3Count     int64
+	Score4Count     int64
+	Score5Count     int64
+	MediaCount      int64
+	ReplyCount      int64
+}
+
+// statTable 评分统计表及其在review_info中对应的分组字段
+type statTable struct {
+	name      string
+	keyColumn string
+}
+
+var statTables = []statTable{
+	{name: "store_rating_stat", keyColumn: "store_id"},
+	{name: "spu_rating_stat", keyColumn: "spu_id"},
+}
+
+var statColumns = []string{
+	"review_count", "score_sum", "service_score_sum", "express_score_sum",
+	"score1_count", "score2_count", "score3_count", "score4_count", "score5_count",
+	"media_count", "reply_count",
+}
+
+// 从review_info重新计算统计的select语句，只统计审核通过且未删除的评论
+const recomputeSelect = `%s AS ` + "`key`" + `,
+	COUNT(*) AS review_count,
+	COALESCE(SUM(score), 0) AS score_sum,
+	COALESCE(SUM(service_score), 0) AS service_score_sum,
+	COALESCE(SUM(express_score), 0) AS express_score_sum,
+	COALESCE(SUM(score = 1), 0) AS score1_count,
+	COALESCE(SUM(score = 2), 0) AS score2_count,
+	COALESCE(SUM(score = 3), 0) AS score3_count,
+	COALESCE(SUM(score = 4), 0) AS score4_count,
+	COALESCE(SUM(score = 5), 0) AS score5_count,
+	COALESCE(SUM(has_media), 0) AS media_count,
+	COALESCE(SUM(has_reply), 0) AS reply_count`
+
+func main() {
+	flag.Parse()
+
+	c := config.New(
+		config.WithSource(
+			file.NewSource(flagconf),
+		),
+	)
+	defer c.Close()
+
+	if err := c.Load(); err != nil {
+		panic(err)
+	}
+
+	var bc conf.Bootstrap
+	if err := c.Scan(&bc); err != nil {
+		panic(err)
+	}
+
+	db, err := gorm.Open(mysql.Open(bc.Data.Database.Source), &gorm.Config{})
+	if err != nil {
+		panic(err)
+	}
+
+	for _, t := range statTables {
+		drifted, err := reconcile(db, t)
+		if err != nil {
+			log.Fatalf("核对%s失败: %v", t.name, err)
+		}
+		log.Infof("核对%s完成，偏差%d条", t.name, drifted)
+	}
+}
+
+// reconcile 对比统计表与review_info的实时统计，输出偏差，开启fix时逐条修复
+func reconcile(db *gorm.DB, t statTable) (int, error) {
+	expected, err := recompute(db, t, 0)
+	if err != nil {
+		return 0, err
+	}
+	var actualRows []statRow
+	err = db.Table(t.name).
+		Select(fmt.Sprintf("%s AS `key`, %s", t.keyColumn, strings.Join(statColumns, ", "))).
+		Scan(&actualRows).Error
+	if err != nil {
+		return 0, err
+	}
+	actual := make(map[int64]statRow, len(actualRows))
+	for _, row := range actualRows {
+		actual[row.Key] = row
+	}
+
+	keys := make(map[int64]struct{}, len(expected)+len(actual))
+	for key := range expected {
+		keys[key] = struct{}{}
+	}
+	for key := range actual {
+		keys[key] = struct{}{}
+	}
+	drifted := 0
+	for key := range keys {
+		want, got := expected[key], actual[key]
+		want.Key, got.Key = key, key
+		if want == got {
+			continue
+		}
+		drifted++
+		log.Warnf("%s[%s:%d]统计偏差，统计表: %+v，实际: %+v", t.name, t.keyColumn, key, got, want)
+		if !fix {
+			continue
+		}
+		if err := repair(db, t, key); err != nil {
+			return drifted, err
+		}
+	}
+	return drifted, nil
+}
+
+// recompute 按分组字段从review_info重新计算统计，key大于0时只计算该分组
+func recompute(db *gorm.DB, t statTable, key int64) (map[int64]statRow, error) {
+	do := db.Table("review_info").
+		Select(fmt.Sprintf(recomputeSelect, t.keyColumn)).
+		Where("status = ? AND delete_at IS NULL AND "+t.keyColumn+" > 0", biz.ReviewStatusApproved)
+	if key > 0 {
+		do = do.Where(t.keyColumn+" = ?", key)
+	}
+	var rows []statRow
+	if err := do.Group(t.keyColumn).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[int64]statRow, len(rows))
+	for _, row := range rows {
+		result[row.Key] = row
+	}
+	return result, nil
+}
+
+// repair 在事务中锁住统计行后重新计算并覆盖写入。
+// 线上增量更新也会先写统计行，加锁后两者串行，修复期间的增量不会丢失。
+func repair(db *gorm.DB, t statTable, key int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var ids []int64
+		err := tx.Table(t.name).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(t.keyColumn+" = ?", key).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		rows, err := recompute(tx, t, key)
+		if err != nil {
+			return err
+		}
+		row := rows[key]
+		values := map[string]interface{}{
+			t.keyColumn:         key,
+			"review_count":      row.ReviewCount,
+			"score_sum":         row.ScoreSum,
+			"service_score_sum": row.ServiceScoreSum,
+			"express_score_sum": row.ExpressScoreSum,
+			"score1_count":      row.Score1Count,
+			"score2_count":      row.Score2Count,
+			"score3_count":      row.Score3Count,
+			"score4_count":      row.Score4Count,
+			"score5_count":      row.Score5Count,
+			"media_count":       row.MediaCount,
+			"reply_count":       row.ReplyCount,
+		}
+		return tx.Table(t.name).
+			Clauses(clause.OnConflict{DoUpdates: clause.AssignmentColumns(statColumns)}).
+			Create(values).Error
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"review-service/internal/conf"
+	"review-service/internal/server"
 	"review-service/pkg/snowflake"
 
 	"github.com/go-kratos/kratos/contrib/registry/consul/v2"
@@ -41,7 +42,7 @@ func Init(name string, version string, machine_id string){
 	id = machine_id
 }
 
-func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, reg *consul.Registry, node *conf.Node) *kratos.App {	
+func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, idx *server.ReviewIndexer, dr *server.DefaultReviewer, reg *consul.Registry, node *conf.Node) *kratos.App {	
 	return kratos.New(
 		kratos.ID(id),
 		kratos.Name(Name),
@@ -51,6 +52,8 @@ func newApp(logger log.Logger, gs *grpc.Server, hs *http.Server, reg *consul.Reg
 		kratos.Server(
 			gs,
 			hs,
+			idx,
+			dr,
 		),
 		// 注册中心
 		kratos.Registrar(reg),
@@ -89,7 +92,7 @@ func main() {
 		"span.id", tracing.SpanID(),
 	)
 
-	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Registry, bc.Node, bc.Elasticsearch, logger)
+	app, cleanup, err := wireApp(bc.Server, bc.Data, bc.Registry, bc.Node, bc.Elasticsearch, bc.Review, logger)
 	if err != nil {
 		panic(err)
 	}
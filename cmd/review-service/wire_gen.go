@@ -23,7 +23,7 @@ import (
 // Injectors from wire.go:
 
 // wireApp init kratos application.
-func wireApp(confServer *conf.Server, confData *conf.Data, registry *conf.Registry, node *conf.Node, elasticsearch *conf.Elasticsearch, logger log.Logger) (*kratos.App, func(), error) {
+func wireApp(confServer *conf.Server, confData *conf.Data, registry *conf.Registry, node *conf.Node, elasticsearch *conf.Elasticsearch, review *conf.Review, logger log.Logger) (*kratos.App, func(), error) {
 	db := data.NewDB(confData)
 	client := data.NewRedis(confData)
 	typedClient := data.NewEsClient(elasticsearch)
@@ -32,13 +32,54 @@ func wireApp(confServer *conf.Server, confData *conf.Data, registry *conf.Regist
 		return nil, nil, err
 	}
 	reviewRepo := data.NewReviewRepo(dataData, logger)
-	reviewUsecase := biz.NewReviewUsecase(reviewRepo, logger)
-	reviewService := service.NewReviewService(reviewUsecase)
-	grpcServer := server.NewGRPCServer(confServer, reviewService, logger)
-	httpServer := server.NewHTTPServer(confServer, reviewService, logger)
 	consulRegistry := server.NewConsulRegistrar(registry)
-	app := newApp(logger, grpcServer, httpServer, consulRegistry, node)
+	orderClient, cleanup2, err := data.NewOrderServiceClient(confData, consulRegistry)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	bizOrderClient := data.NewOrderClient(orderClient, logger)
+	productClient, cleanup3, err := data.NewProductServiceClient(confData, consulRegistry)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	bizProductClient := data.NewProductClient(productClient, logger)
+	reviewUsecase := biz.NewReviewUsecase(review, reviewRepo, bizOrderClient, bizProductClient, logger)
+	userClient, cleanup4, err := data.NewUserServiceClient(confData, consulRegistry)
+	if err != nil {
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	bizUserClient := data.NewUserClient(userClient, logger)
+	reviewAuthorUsecase, err := biz.NewReviewAuthorUsecase(review, bizUserClient, logger)
+	if err != nil {
+		cleanup4()
+		cleanup3()
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	reviewService := service.NewReviewService(reviewUsecase, reviewAuthorUsecase)
+	appealRepo := data.NewAppealRepo(dataData, logger)
+	appealUsecase := biz.NewAppealUsecase(appealRepo, logger)
+	appealService := service.NewAppealService(appealUsecase)
+	grpcServer := server.NewGRPCServer(confServer, reviewService, appealService, logger)
+	httpServer := server.NewHTTPServer(confServer, reviewService, appealService, logger)
+	reviewIndexRepo := data.NewReviewIndexRepo(dataData, logger)
+	reviewIndexUsecase := biz.NewReviewIndexUsecase(reviewIndexRepo, logger)
+	reviewIndexer := server.NewReviewIndexer(confServer, reviewIndexUsecase, logger)
+	defaultReviewRepo := data.NewDefaultReviewRepo(dataData, logger)
+	defaultReviewUsecase := biz.NewDefaultReviewUsecase(review, defaultReviewRepo, reviewUsecase, logger)
+	defaultReviewer := server.NewDefaultReviewer(confServer, defaultReviewUsecase, logger)
+	app := newApp(logger, grpcServer, httpServer, reviewIndexer, defaultReviewer, consulRegistry, node)
 	return app, func() {
+		cleanup4()
+		cleanup3()
+		cleanup2()
 		cleanup()
 	}, nil
 }
@@ -2,11 +2,20 @@ package biz
 
 import (
 	"context"
+	"errors"
 	v1 "review-service/api/review/v1"
 	"review-service/internal/data/model"
 	"review-service/pkg/snowflake"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// 申诉状态
+const (
+	AppealStatusPending  int32 = 10 // 待审核
+	AppealStatusApproved int32 = 20 // 申诉通过
+	AppealStatusRejected int32 = 30 // 申诉驳回
 )
 
 type Appeal struct {
@@ -19,10 +28,24 @@ type Appeal struct {
 	Status    int32 // 审核状态
 }
 
+// 运营审核申诉
+type AuditAppeal struct {
+	AppealID  int64
+	ReviewID  int64
+	Status    int32 // 审核结果：20申诉通过；30申诉驳回
+	OpUser    string
+	OpRemarks string
+}
+
+// ErrAppealAudited 申诉已被审核，并发审核时只有一个成功
+var ErrAppealAudited = errors.New("appeal audited")
+
 type AppealRepo interface {
 	SaveAppeal(context.Context, *Appeal) (int64, error)
 	GetReviewByReviewID(context.Context, int64) (*model.ReviewInfo, error)
-	UpdateReviewStatus(context.Context, int64, int32) error
+	GetAppealByAppealID(context.Context, int64) (*model.ReviewAppealInfo, error)
+	GetPendingAppealByReviewID(context.Context, int64) (*model.ReviewAppealInfo, error)
+	AuditAppeal(context.Context, *AuditAppeal) error
 }
 
 type AppealUsecase struct {
@@ -37,22 +60,34 @@ func NewAppealUsecase(repo AppealRepo, logger log.Logger) *AppealUsecase {
 // SaveAppeal 创建申诉
 func (uc *AppealUsecase) SaveAppeal(ctx context.Context, appeal *Appeal) (int64, error) {
 	uc.log.WithContext(ctx).Infof("SaveAppeal: %v", appeal)
-	// 1 若评论申诉过，不能重复申诉
+	// 1 评论必须存在
 	review, err := uc.repo.GetReviewByReviewID(ctx, appeal.ReviewID)
-	if err != nil {
-		return 0, err
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.log.WithContext(ctx).Errorf("评论查询失败[review_id:%d]，%v", appeal.ReviewID, err)
+		return 0, v1.ErrorGormBadErr("评论查询失败")
 	}
 	if review == nil {
 		uc.log.WithContext(ctx).Warnf("评论不存在[review_id:%d]", appeal.ReviewID)
 		return 0, v1.ErrorGormBadErr("评论不存在")
 	}
-	if review.Status >= 10 {
+	// 不能水平越权【A商家不能申诉B商家下用户的评论】
+	if review.StoreID != appeal.StoreID {
+		uc.log.WithContext(ctx).Warnf("商家无权限申诉评论[store_id:%d review_id:%d]", appeal.StoreID, appeal.ReviewID)
+		return 0, v1.ErrorReviewUnauthorizedAccess("水平越权")
+	}
+	// 2 若评论有待审核的申诉，不能重复申诉
+	pending, err := uc.repo.GetPendingAppealByReviewID(ctx, appeal.ReviewID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.log.WithContext(ctx).Errorf("申诉查询失败[review_id:%d]，%v", appeal.ReviewID, err)
+		return 0, v1.ErrorGormBadErr("申诉查询失败")
+	}
+	if pending != nil {
 		uc.log.WithContext(ctx).Warnf("评论已申诉[review_id:%d]，不能重复申诉", appeal.ReviewID)
 		return 0, v1.ErrorReviewAppealedErr("评论已申诉，不能重复申诉")
 	}
-	// 2 创建申诉记录，并设置评论为待审核状态
+	// 3 创建待审核的申诉记录，评论的审核状态保持不变
 	appeal.AppealID = snowflake.GenID()
-	appeal.Status = 10
+	appeal.Status = AppealStatusPending
 	appealID, err := uc.repo.SaveAppeal(ctx, appeal)
 	if err != nil {
 		uc.log.WithContext(ctx).Errorf("创建申诉失败[review_id:%d]，%v", appeal.ReviewID, err)
@@ -60,3 +95,54 @@ func (uc *AppealUsecase) SaveAppeal(ctx context.Context, appeal *Appeal) (int64,
 	}
 	return appealID, nil
 }
+
+// AuditAppeal 运营审核申诉
+func (uc *AppealUsecase) AuditAppeal(ctx context.Context, audit *AuditAppeal) error {
+	uc.log.WithContext(ctx).Infof("AuditAppeal: %v", audit)
+	// 1 只能审核为通过或驳回
+	if audit.Status != AppealStatusApproved && audit.Status != AppealStatusRejected {
+		return v1.ErrorAppealAuditedErr("申诉审核状态不合法[status:%d]", audit.Status)
+	}
+	// 2 只有待审核的申诉可以审核
+	appeal, err := uc.repo.GetAppealByAppealID(ctx, audit.AppealID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.log.WithContext(ctx).Errorf("申诉查询失败[appeal_id:%d]，%v", audit.AppealID, err)
+		return v1.ErrorGormBadErr("申诉查询失败")
+	}
+	if appeal == nil {
+		uc.log.WithContext(ctx).Warnf("申诉不存在[appeal_id:%d]", audit.AppealID)
+		return v1.ErrorGormBadErr("申诉不存在")
+	}
+	if appeal.Status != AppealStatusPending {
+		uc.log.WithContext(ctx).Warnf("申诉已审核[appeal_id:%d]，不能重复审核", audit.AppealID)
+		return v1.ErrorAppealAuditedErr("申诉已审核，不能重复审核")
+	}
+	// 3 申诉通过需要隐藏评论，校验评论状态流转
+	audit.ReviewID = appeal.ReviewID
+	if audit.Status == AppealStatusApproved {
+		review, err := uc.repo.GetReviewByReviewID(ctx, appeal.ReviewID)
+		if err != nil {
+			uc.log.WithContext(ctx).Errorf("评论查询失败[review_id:%d]，%v", appeal.ReviewID, err)
+			return v1.ErrorGormBadErr("评论查询失败")
+		}
+		if !CanTransitReviewStatus(review.Status, ReviewStatusHidden) {
+			uc.log.WithContext(ctx).Warnf("评论状态不能从%d流转到%d[review_id:%d]", review.Status, ReviewStatusHidden, appeal.ReviewID)
+			return v1.ErrorReviewStatusTransitionErr("评论状态不能从%d变更为%d", review.Status, ReviewStatusHidden)
+		}
+	}
+	// 4 更新申诉状态，申诉通过时同一事务内隐藏评论
+	err = uc.repo.AuditAppeal(ctx, audit)
+	if errors.Is(err, ErrAppealAudited) {
+		uc.log.WithContext(ctx).Warnf("申诉已审核[appeal_id:%d]，不能重复审核", audit.AppealID)
+		return v1.ErrorAppealAuditedErr("申诉已审核，不能重复审核")
+	}
+	if errors.Is(err, ErrReviewStatusChanged) {
+		uc.log.WithContext(ctx).Warnf("评论状态已变更[review_id:%d]，无法隐藏", audit.ReviewID)
+		return v1.ErrorReviewStatusTransitionErr("评论状态已变更，无法隐藏")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("审核申诉失败[appeal_id:%d]，%v", audit.AppealID, err)
+		return err
+	}
+	return nil
+}
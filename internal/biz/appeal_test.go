@@ -0,0 +1,72 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// memoryAppealRepo 内存版申诉repo，用于测试，只实现创建申诉相关的方法
+type memoryAppealRepo struct {
+	AppealRepo
+	reviews map[int64]*model.ReviewInfo
+	appeals []*Appeal
+}
+
+func (r *memoryAppealRepo) GetReviewByReviewID(ctx context.Context, reviewID int64) (*model.ReviewInfo, error) {
+	if review, ok := r.reviews[reviewID]; ok {
+		return review, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryAppealRepo) GetPendingAppealByReviewID(ctx context.Context, reviewID int64) (*model.ReviewAppealInfo, error) {
+	for _, appeal := range r.appeals {
+		if appeal.ReviewID == reviewID && appeal.Status == AppealStatusPending {
+			return &model.ReviewAppealInfo{AppealID: appeal.AppealID, ReviewID: appeal.ReviewID, Status: appeal.Status}, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryAppealRepo) SaveAppeal(ctx context.Context, appeal *Appeal) (int64, error) {
+	r.appeals = append(r.appeals, appeal)
+	return appeal.AppealID, nil
+}
+
+func TestSaveAppeal(t *testing.T) {
+	repo := &memoryAppealRepo{reviews: map[int64]*model.ReviewInfo{
+		1: {ReviewID: 1, StoreID: 100, Status: ReviewStatusApproved},
+	}}
+	uc := NewAppealUsecase(repo, log.DefaultLogger)
+
+	tests := []struct {
+		name   string
+		appeal *Appeal
+		want   *errors.Error
+	}{
+		{"other store", &Appeal{ReviewID: 1, StoreID: 101, Content: "恶意差评"}, v1.ErrorReviewUnauthorizedAccess("")},
+		{"own store", &Appeal{ReviewID: 1, StoreID: 100, Content: "恶意差评"}, nil},
+		{"pending appeal", &Appeal{ReviewID: 1, StoreID: 100, Content: "恶意差评"}, v1.ErrorReviewAppealedErr("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.SaveAppeal(context.Background(), tt.appeal)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("want no error, got %v", err)
+				}
+				return
+			}
+			if got := errors.Reason(err); got != tt.want.Reason {
+				t.Fatalf("want reason %s, got %v", tt.want.Reason, err)
+			}
+		})
+	}
+}
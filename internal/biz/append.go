@@ -0,0 +1,114 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+	"review-service/pkg/snowflake"
+
+	"gorm.io/gorm"
+)
+
+// 默认追评期限
+const defaultAppendWindow = 90 * 24 * time.Hour
+
+// 用户追评
+type ReviewAppend struct {
+	ReviewID  int64
+	UserID    int64
+	Content   string
+	PicInfo   string
+	VideoInfo string
+}
+
+// 运营审核追评
+type AuditReviewAppend struct {
+	AppendID  int64
+	Status    int32
+	OpReason  string
+	OpRemarks string
+	OpUser    string
+}
+
+// ReviewAppendInfo 评价索引中的追评，只包含审核通过的追评
+type ReviewAppendInfo struct {
+	AppendID  int64  `json:"append_id,string"`
+	Content   string `json:"content"`
+	PicInfo   string `json:"pic_info"`
+	VideoInfo string `json:"video_info"`
+	CreateAt  Mytime `json:"create_at"`
+}
+
+// 追评，每条评论只能追评一次，且需在原评论创建后的追评期限内
+func (uc *ReviewUsecase) AppendReview(ctx context.Context, a *ReviewAppend) (int64, error) {
+	// 1. 原评论必须存在且属于当前用户
+	review, err := uc.repo.GetReviewByReviewID(ctx, a.ReviewID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.log.WithContext(ctx).Errorf("评论id:%d查询失败, err:%v", a.ReviewID, err)
+		return 0, v1.ErrorGormBadErr("评论查询失败")
+	}
+	if review == nil {
+		return 0, v1.ErrorGormBadErr("评论不存在，无法追评")
+	}
+	if review.UserID != a.UserID {
+		uc.log.WithContext(ctx).Warnf("用户id:%d无权限追评评论id:%d", a.UserID, a.ReviewID)
+		return 0, v1.ErrorReviewUnauthorizedAccess("水平越权")
+	}
+
+	// 2. 追评期限
+	if time.Since(review.CreateAt) > uc.appendWindow {
+		return 0, v1.ErrorParamErr("已超过追评期限")
+	}
+
+	// 3. 每条评论只能追评一次
+	existing, err := uc.repo.GetReviewAppendByReviewID(ctx, a.ReviewID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.log.WithContext(ctx).Errorf("评论id:%d追评查询失败, err:%v", a.ReviewID, err)
+		return 0, v1.ErrorGormBadErr("追评查询失败")
+	}
+	if existing != nil {
+		return 0, v1.ErrorReviewRepeatedErr("评论已追评")
+	}
+
+	// 4. 追评入库，待运营审核
+	appendID, err := uc.repo.SaveReviewAppend(ctx, &model.ReviewAppendInfo{
+		AppendID:  snowflake.GenID(),
+		ReviewID:  a.ReviewID,
+		UserID:    a.UserID,
+		Content:   a.Content,
+		PicInfo:   a.PicInfo,
+		VideoInfo: a.VideoInfo,
+		Status:    ReviewStatusPending,
+	})
+	if errors.Is(err, ErrReviewAppended) {
+		return 0, v1.ErrorReviewRepeatedErr("评论已追评")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("评论id:%d追评失败, err:%v", a.ReviewID, err)
+		return 0, v1.ErrorGormBadErr("追评失败")
+	}
+	return appendID, nil
+}
+
+// 运营审核追评，追评与原评论独立审核
+func (uc *ReviewUsecase) AuditReviewAppend(ctx context.Context, audit *AuditReviewAppend) error {
+	reviewAppend, err := uc.repo.GetReviewAppendByAppendID(ctx, audit.AppendID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return v1.ErrorGormBadErr("追评不存在")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("追评id:%d查询失败, err:%v", audit.AppendID, err)
+		return v1.ErrorGormBadErr("追评查询失败")
+	}
+	if !CanTransitReviewStatus(reviewAppend.Status, audit.Status) {
+		return v1.ErrorReviewStatusTransitionErr("追评状态不能从%d变更为%d", reviewAppend.Status, audit.Status)
+	}
+	if err := uc.repo.AuditReviewAppend(ctx, reviewAppend.ReviewID, audit, reviewAppend.Status); err != nil {
+		uc.log.WithContext(ctx).Errorf("追评id:%d审核失败, err:%v", audit.AppendID, err)
+		return v1.ErrorGormBadErr("追评审核失败")
+	}
+	return nil
+}
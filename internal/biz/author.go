@@ -0,0 +1,148 @@
+package biz
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+
+	"review-service/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 匿名用户默认昵称，用户资料查询不到时使用
+const AnonymousNickname = "匿名用户"
+
+// 调用方角色
+type ViewerRole int32
+
+const (
+	ViewerRoleGuest    ViewerRole = iota // 未登录
+	ViewerRoleUser                       // 普通用户
+	ViewerRoleMerchant                   // 商家
+	ViewerRoleOperator                   // 运营
+)
+
+// Viewer 调用方身份，由网关透传
+type Viewer struct {
+	UserID int64
+	Role   ViewerRole
+}
+
+// CanSeeAuthor 匿名评论只有作者本人和运营可以看到真实用户
+func (v *Viewer) CanSeeAuthor(userID int64) bool {
+	if v == nil {
+		return false
+	}
+	if v.Role == ViewerRoleOperator {
+		return true
+	}
+	return v.Role == ViewerRoleUser && v.UserID > 0 && v.UserID == userID
+}
+
+// CanSeeReview 审核通过的评论所有人可见，其他状态的评论只有作者本人和运营可见
+func (v *Viewer) CanSeeReview(status int32, userID int64) bool {
+	return status == ReviewStatusApproved || v.CanSeeAuthor(userID)
+}
+
+// AuthorRef 待展示作者信息的评论
+type AuthorRef struct {
+	ReviewID  int64
+	UserID    int64
+	Anonymous bool
+}
+
+// ReviewAuthor 评论作者的展示信息，匿名评论对无权限的调用方UserID为0，
+// 使用每条评论独立的Token代替，不同评论之间无法关联到同一用户
+type ReviewAuthor struct {
+	UserID   int64
+	Token    string
+	Nickname string
+	Avatar   string
+	Masked   bool // 已脱敏，订单号等可关联到用户的信息也不能展示
+}
+
+// ReviewAuthorUsecase 评论作者展示，按调用方角色对匿名评论脱敏
+type ReviewAuthorUsecase struct {
+	userClient UserClient
+	secret     []byte
+	log        *log.Helper
+}
+
+// NewReviewAuthorUsecase .
+func NewReviewAuthorUsecase(c *conf.Review, userClient UserClient, logger log.Logger) (*ReviewAuthorUsecase, error) {
+	uc := &ReviewAuthorUsecase{userClient: userClient, secret: []byte(c.GetAnonymousSecret()), log: log.NewHelper(logger)}
+	if len(uc.secret) == 0 {
+		// 未配置时使用随机密钥，重启或多副本之间同一评论的Token会不一致
+		uc.secret = make([]byte, 32)
+		if _, err := rand.Read(uc.secret); err != nil {
+			return nil, err
+		}
+		uc.log.Warn("未配置匿名评论签名密钥，使用随机密钥")
+	}
+	return uc, nil
+}
+
+// ResolveAuthors 查询评论作者资料并按调用方角色脱敏，返回结果与refs一一对应。
+// 用户服务不可用时降级为不展示昵称头像，不影响评论读取
+func (uc *ReviewAuthorUsecase) ResolveAuthors(ctx context.Context, viewer *Viewer, refs []*AuthorRef) []*ReviewAuthor {
+	userIDs := make([]int64, 0, len(refs))
+	seen := make(map[int64]struct{}, len(refs))
+	for _, ref := range refs {
+		if _, ok := seen[ref.UserID]; ok || ref.UserID <= 0 {
+			continue
+		}
+		seen[ref.UserID] = struct{}{}
+		userIDs = append(userIDs, ref.UserID)
+	}
+	var profiles map[int64]*UserProfile
+	if len(userIDs) > 0 {
+		var err error
+		profiles, err = uc.userClient.BatchGetUsers(ctx, userIDs)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("查询评论用户资料失败: %v", err)
+		}
+	}
+	authors := make([]*ReviewAuthor, len(refs))
+	for i, ref := range refs {
+		author := &ReviewAuthor{UserID: ref.UserID}
+		if profile, ok := profiles[ref.UserID]; ok {
+			author.Nickname = profile.Nickname
+			author.Avatar = profile.Avatar
+		}
+		if ref.Anonymous {
+			author.Token = uc.anonymousToken(ref.ReviewID)
+			if !viewer.CanSeeAuthor(ref.UserID) {
+				author.UserID = 0
+				author.Nickname = maskNickname(author.Nickname)
+				author.Avatar = ""
+				author.Masked = true
+			}
+		}
+		authors[i] = author
+	}
+	return authors
+}
+
+// anonymousToken 按评论ID签名生成匿名标识，同一评论稳定不变，无法反推用户
+func (uc *ReviewAuthorUsecase) anonymousToken(reviewID int64) string {
+	mac := hmac.New(sha256.New, uc.secret)
+	mac.Write([]byte(strconv.FormatInt(reviewID, 10)))
+	return hex.EncodeToString(mac.Sum(nil)[:12])
+}
+
+// maskNickname 昵称只保留首尾字符，如"张三丰"展示为"张***丰"
+func maskNickname(nickname string) string {
+	runes := []rune(nickname)
+	switch len(runes) {
+	case 0:
+		return AnonymousNickname
+	case 1:
+		return string(runes) + "***"
+	default:
+		return string(runes[0]) + "***" + string(runes[len(runes)-1])
+	}
+}
@@ -0,0 +1,114 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	"review-service/internal/conf"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestResolveAuthors(t *testing.T) {
+	users := NewMemoryUserClient(
+		&UserProfile{UserID: 10, Nickname: "张三丰", Avatar: "https://img.example.com/10.jpg"},
+		&UserProfile{UserID: 11, Nickname: "李四", Avatar: "https://img.example.com/11.jpg"},
+	)
+	uc, err := NewReviewAuthorUsecase(&conf.Review{AnonymousSecret: "secret"}, users, log.DefaultLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := []*AuthorRef{
+		{ReviewID: 1, UserID: 10, Anonymous: true},
+		{ReviewID: 2, UserID: 10, Anonymous: true},
+		{ReviewID: 3, UserID: 11},
+	}
+
+	tests := []struct {
+		name   string
+		viewer *Viewer
+		masked bool
+	}{
+		{"guest", &Viewer{Role: ViewerRoleGuest}, true},
+		{"other user", &Viewer{UserID: 11, Role: ViewerRoleUser}, true},
+		{"merchant", &Viewer{UserID: 10, Role: ViewerRoleMerchant}, true},
+		{"owner", &Viewer{UserID: 10, Role: ViewerRoleUser}, false},
+		{"operator", &Viewer{Role: ViewerRoleOperator}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authors := uc.ResolveAuthors(context.Background(), tt.viewer, refs)
+			if len(authors) != len(refs) {
+				t.Fatalf("want %d authors, got %d", len(refs), len(authors))
+			}
+			for _, a := range authors[:2] {
+				if a.Token == "" {
+					t.Fatalf("anonymous review should have a token")
+				}
+				if a.Masked != tt.masked {
+					t.Fatalf("want masked %t, got %+v", tt.masked, a)
+				}
+				if tt.masked && (a.UserID != 0 || a.Nickname != "张***丰" || a.Avatar != "") {
+					t.Fatalf("author not masked: %+v", a)
+				}
+				if !tt.masked && (a.UserID != 10 || a.Nickname != "张三丰" || a.Avatar == "") {
+					t.Fatalf("author should be visible: %+v", a)
+				}
+			}
+			// 同一用户的不同匿名评论Token不同，无法关联
+			if authors[0].Token == authors[1].Token {
+				t.Fatalf("tokens of different reviews should differ")
+			}
+			if a := authors[2]; a.UserID != 11 || a.Nickname != "李四" || a.Token != "" || a.Masked {
+				t.Fatalf("non-anonymous author should be unchanged: %+v", a)
+			}
+		})
+	}
+}
+
+func TestAnonymousTokenStable(t *testing.T) {
+	users := NewMemoryUserClient()
+	uc1, _ := NewReviewAuthorUsecase(&conf.Review{AnonymousSecret: "secret"}, users, log.DefaultLogger)
+	uc2, _ := NewReviewAuthorUsecase(&conf.Review{AnonymousSecret: "secret"}, users, log.DefaultLogger)
+	if uc1.anonymousToken(1) != uc2.anonymousToken(1) {
+		t.Fatalf("same secret should produce the same token")
+	}
+}
+
+func TestMaskNickname(t *testing.T) {
+	tests := map[string]string{
+		"":    AnonymousNickname,
+		"张":   "张***",
+		"李四":  "李***四",
+		"张三丰": "张***丰",
+		"tom": "t***m",
+	}
+	for in, want := range tests {
+		if got := maskNickname(in); got != want {
+			t.Errorf("maskNickname(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCanSeeReview(t *testing.T) {
+	tests := []struct {
+		name   string
+		viewer *Viewer
+		status int32
+		want   bool
+	}{
+		{"guest approved", &Viewer{Role: ViewerRoleGuest}, ReviewStatusApproved, true},
+		{"guest pending", &Viewer{Role: ViewerRoleGuest}, ReviewStatusPending, false},
+		{"other user hidden", &Viewer{UserID: 11, Role: ViewerRoleUser}, ReviewStatusHidden, false},
+		{"merchant rejected", &Viewer{UserID: 10, Role: ViewerRoleMerchant}, ReviewStatusRejected, false},
+		{"owner pending", &Viewer{UserID: 10, Role: ViewerRoleUser}, ReviewStatusPending, true},
+		{"operator hidden", &Viewer{Role: ViewerRoleOperator}, ReviewStatusHidden, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.viewer.CanSeeReview(tt.status, 10); got != tt.want {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -8,7 +8,7 @@ import (
 )
 
 // ProviderSet is biz providers.
-var ProviderSet = wire.NewSet(NewReviewUsecase)
+var ProviderSet = wire.NewSet(NewReviewUsecase, NewAppealUsecase, NewReviewIndexUsecase, NewDefaultReviewUsecase, NewReviewAuthorUsecase)
 
 // ReviewInfo 评价表
 type ReviewInfo struct {
@@ -43,6 +43,8 @@ type ReviewInfo struct {
 	GoodsSnapshoot string  `json:"goods_snapshoot"`
 	ExtJSON        string  `json:"ext_json"`
 	CtrlJSON       string  `json:"ctrl_json"`
+
+	Append *ReviewAppendInfo `json:"append,omitempty"` // 审核通过的追评
 }
 
 type Mytime time.Time
@@ -65,3 +67,11 @@ func (mt *Mytime) UnmarshalJSON(data []byte) error {
 	*mt = Mytime(t)
 	return nil
 }
+
+func (mt Mytime) MarshalJSON() ([]byte, error) {
+	t := time.Time(mt)
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(`"` + t.Format(time.DateTime) + `"`), nil
+}
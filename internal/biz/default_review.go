@@ -0,0 +1,189 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/conf"
+	"review-service/internal/data/model"
+	"review-service/pkg/snowflake"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 默认评价内容
+const DefaultReviewContent = "系统默认好评"
+
+// 默认评价评分
+const DefaultReviewScore int32 = 5
+
+// 处理失败的订单最多重试次数，超过后转入死信等待人工处理
+const maxDefaultReviewAttempts = 5
+
+// DefaultReviewRepo 默认评价任务repo，分布式锁保证多副本只有一个执行，检查点记录订单拉取进度，
+// 处理失败的订单暂存到重试队列，不阻塞检查点推进
+type DefaultReviewRepo interface {
+	TryLock(context.Context, time.Duration) (release func(), ok bool, err error)
+	GetCheckpoint(context.Context) (string, error)
+	SaveCheckpoint(context.Context, string) error
+	// ParkOrder 订单加入重试队列并返回累计失败次数
+	ParkOrder(ctx context.Context, orderID int64) (int, error)
+	// ListParkedOrders 按失败次数从少到多返回重试队列中的订单
+	ListParkedOrders(ctx context.Context, size int) ([]int64, error)
+	UnparkOrder(ctx context.Context, orderID int64) error
+	// DeadLetterOrder 订单移出重试队列并转入死信
+	DeadLetterOrder(ctx context.Context, orderID int64) error
+}
+
+// DefaultReviewUsecase 订单完成后超时未评价的商品自动生成默认好评
+type DefaultReviewUsecase struct {
+	repo   DefaultReviewRepo
+	review *ReviewUsecase
+	after  time.Duration
+	log    *log.Helper
+}
+
+// NewDefaultReviewUsecase 默认评价在评价期限结束后生成，避免占用用户还能提交评论的商品，
+// 未配置或配置小于评价期限时按评价期限处理
+func NewDefaultReviewUsecase(c *conf.Review, repo DefaultReviewRepo, review *ReviewUsecase, logger log.Logger) *DefaultReviewUsecase {
+	uc := &DefaultReviewUsecase{repo: repo, review: review, after: review.reviewWindow, log: log.NewHelper(logger)}
+	if c.GetDefaultReviewAfter() != nil {
+		if after := c.GetDefaultReviewAfter().AsDuration(); after >= review.reviewWindow {
+			uc.after = after
+		} else {
+			uc.log.Warnf("默认评价等待时长%s小于评价期限%s，按评价期限处理", after, review.reviewWindow)
+		}
+	}
+	return uc
+}
+
+// RunOnce 先重试一批之前失败的订单，再拉取一批到期的已完成订单并生成默认评价，返回新拉取的订单数，未抢到锁时返回0。
+// 单个订单处理失败时加入重试队列，检查点照常推进；加入重试队列失败时不推进检查点，下次重新处理该批订单，已评价的商品会被跳过
+func (uc *DefaultReviewUsecase) RunOnce(ctx context.Context, batchSize int, lockTTL time.Duration) (int, error) {
+	release, ok, err := uc.repo.TryLock(ctx, lockTTL)
+	if err != nil || !ok {
+		return 0, err
+	}
+	defer release()
+
+	if err := uc.retryParkedOrders(ctx, batchSize); err != nil {
+		return 0, err
+	}
+	cursor, err := uc.repo.GetCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	orders, nextCursor, err := uc.review.orderClient.ListCompletedOrders(ctx, cursor, time.Now().Add(-uc.after), batchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, order := range orders {
+		if err := uc.review.createDefaultReviews(ctx, order); err != nil {
+			if err := uc.parkOrder(ctx, order.OrderID, err); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if nextCursor != "" {
+		if err := uc.repo.SaveCheckpoint(ctx, nextCursor); err != nil {
+			return 0, err
+		}
+	}
+	return len(orders), nil
+}
+
+// retryParkedOrders 重试之前处理失败的订单，成功或订单已不存在时移出重试队列
+func (uc *DefaultReviewUsecase) retryParkedOrders(ctx context.Context, size int) error {
+	orderIDs, err := uc.repo.ListParkedOrders(ctx, size)
+	if err != nil {
+		return err
+	}
+	for _, orderID := range orderIDs {
+		order, err := uc.review.orderClient.GetOrder(ctx, orderID)
+		if err == nil {
+			err = uc.review.createDefaultReviews(ctx, order)
+		} else if errors.Is(err, ErrOrderNotFound) {
+			err = nil
+		}
+		if err != nil {
+			if err := uc.parkOrder(ctx, orderID, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := uc.repo.UnparkOrder(ctx, orderID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parkOrder 记录订单处理失败，失败次数达到上限时转入死信
+func (uc *DefaultReviewUsecase) parkOrder(ctx context.Context, orderID int64, cause error) error {
+	attempts, err := uc.repo.ParkOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if attempts < maxDefaultReviewAttempts {
+		uc.log.WithContext(ctx).Warnf("订单id:%d生成默认评价失败%d次，稍后重试: %v", orderID, attempts, cause)
+		return nil
+	}
+	uc.log.WithContext(ctx).Errorf("订单id:%d生成默认评价失败%d次，转入死信: %v", orderID, attempts, cause)
+	return uc.repo.DeadLetterOrder(ctx, orderID)
+}
+
+// createDefaultReviews 为订单中未评价的商品生成默认好评，默认好评无需审核，按审核通过计入评分统计。
+// 用户评论后又删除的商品也算已评价，不再生成默认好评
+func (uc *ReviewUsecase) createDefaultReviews(ctx context.Context, order *Order) error {
+	if order.Status != OrderStatusCompleted {
+		return nil
+	}
+	skuIDs, err := uc.repo.ListReviewedSkuIDs(ctx, order.OrderID)
+	if err != nil {
+		return err
+	}
+	reviewed := make(map[int64]struct{}, len(skuIDs)+len(order.Items))
+	for _, skuID := range skuIDs {
+		reviewed[skuID] = struct{}{}
+	}
+	reviews := make([]*model.ReviewInfo, 0, len(order.Items))
+	for _, item := range order.Items {
+		if _, ok := reviewed[item.SkuID]; ok {
+			continue
+		}
+		reviewed[item.SkuID] = struct{}{}
+		r := &model.ReviewInfo{
+			ReviewID:     snowflake.GenID(),
+			UserID:       order.UserID,
+			OrderID:      order.OrderID,
+			StoreID:      order.StoreID,
+			SkuID:        item.SkuID,
+			SpuID:        item.SpuID,
+			Content:      DefaultReviewContent,
+			Score:        DefaultReviewScore,
+			ServiceScore: DefaultReviewScore,
+			ExpressScore: DefaultReviewScore,
+			IsDefault:    1,
+			Status:       ReviewStatusApproved,
+		}
+		if err := uc.captureGoodsSnapshot(ctx, r); err != nil {
+			// 商品已下架或信息不匹配时跳过该商品，避免阻塞后续订单
+			if v1.IsSkuNotFound(err) || v1.IsParamErr(err) {
+				uc.log.WithContext(ctx).Warnf("订单id:%d商品sku:%d无法生成默认评价: %v", order.OrderID, item.SkuID, err)
+				continue
+			}
+			return err
+		}
+		reviews = append(reviews, r)
+	}
+	if len(reviews) == 0 {
+		return nil
+	}
+	if _, err := uc.repo.BatchSaveReviews(ctx, reviews); err != nil {
+		return err
+	}
+	uc.log.WithContext(ctx).Infof("订单id:%d生成%d条默认评价", order.OrderID, len(reviews))
+	return nil
+}
@@ -0,0 +1,89 @@
+package biz
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryDefaultReviewRepo 内存版默认评价任务repo，用于测试
+type memoryDefaultReviewRepo struct {
+	mu         sync.Mutex
+	locked     bool
+	checkpoint string
+	parked     map[int64]int
+	dead       map[int64]struct{}
+}
+
+func newMemoryDefaultReviewRepo() *memoryDefaultReviewRepo {
+	return &memoryDefaultReviewRepo{parked: make(map[int64]int), dead: make(map[int64]struct{})}
+}
+
+func (r *memoryDefaultReviewRepo) TryLock(ctx context.Context, ttl time.Duration) (func(), bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.locked {
+		return nil, false, nil
+	}
+	r.locked = true
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.locked = false
+	}, true, nil
+}
+
+func (r *memoryDefaultReviewRepo) GetCheckpoint(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.checkpoint, nil
+}
+
+func (r *memoryDefaultReviewRepo) SaveCheckpoint(ctx context.Context, checkpoint string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkpoint = checkpoint
+	return nil
+}
+
+func (r *memoryDefaultReviewRepo) ParkOrder(ctx context.Context, orderID int64) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.parked[orderID]++
+	return r.parked[orderID], nil
+}
+
+func (r *memoryDefaultReviewRepo) ListParkedOrders(ctx context.Context, size int) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	orderIDs := make([]int64, 0, len(r.parked))
+	for orderID := range r.parked {
+		orderIDs = append(orderIDs, orderID)
+	}
+	sort.Slice(orderIDs, func(i, j int) bool {
+		if r.parked[orderIDs[i]] != r.parked[orderIDs[j]] {
+			return r.parked[orderIDs[i]] < r.parked[orderIDs[j]]
+		}
+		return orderIDs[i] < orderIDs[j]
+	})
+	if len(orderIDs) > size {
+		orderIDs = orderIDs[:size]
+	}
+	return orderIDs, nil
+}
+
+func (r *memoryDefaultReviewRepo) UnparkOrder(ctx context.Context, orderID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.parked, orderID)
+	return nil
+}
+
+func (r *memoryDefaultReviewRepo) DeadLetterOrder(ctx context.Context, orderID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.parked, orderID)
+	r.dead[orderID] = struct{}{}
+	return nil
+}
@@ -0,0 +1,172 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"review-service/internal/data/model"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// unavailableProductClient 查询指定商品时商品服务不可用，其余商品正常返回
+type unavailableProductClient struct {
+	*MemoryProductClient
+	skuID int64
+}
+
+func (c *unavailableProductClient) GetSku(ctx context.Context, skuID int64) (*Sku, error) {
+	if skuID == c.skuID {
+		return nil, errors.New("product service unavailable")
+	}
+	return c.MemoryProductClient.GetSku(ctx, skuID)
+}
+
+func newTestDefaultReviewUsecase(orders *MemoryOrderClient, products ProductClient, reviews *memoryReviewRepo) (*DefaultReviewUsecase, *memoryDefaultReviewRepo) {
+	review := &ReviewUsecase{repo: reviews, orderClient: orders, productClient: products, reviewWindow: defaultReviewWindow,
+		log: log.NewHelper(log.DefaultLogger)}
+	repo := newMemoryDefaultReviewRepo()
+	return &DefaultReviewUsecase{repo: repo, review: review, after: defaultReviewWindow, log: log.NewHelper(log.DefaultLogger)}, repo
+}
+
+// defaultReviewSkus 返回订单下默认评价的商品
+func defaultReviewSkus(reviews *memoryReviewRepo, orderID int64) []int64 {
+	var skuIDs []int64
+	for _, review := range reviews.reviews {
+		if review.OrderID == orderID && review.IsDefault == 1 {
+			skuIDs = append(skuIDs, review.SkuID)
+		}
+	}
+	return skuIDs
+}
+
+func TestRunOnceCreatesDefaultReviews(t *testing.T) {
+	ctx := context.Background()
+	due := time.Now().Add(-defaultReviewWindow - time.Hour)
+	deleted := time.Now()
+	orders := NewMemoryOrderClient(
+		&Order{OrderID: 1, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: due,
+			Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}, {SkuID: 1001, SpuID: 2000}}},
+		&Order{OrderID: 2, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: due.Add(time.Minute),
+			Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}}},
+		// 仍在评价期限内
+		&Order{OrderID: 3, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: time.Now(),
+			Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}}},
+	)
+	products := NewMemoryProductClient(&Sku{SkuID: 1000, SpuID: 2000}, &Sku{SkuID: 1001, SpuID: 2000})
+	// 用户评论后又删除的商品不再生成默认评价，也不能阻塞同一订单的其他商品
+	reviews := &memoryReviewRepo{reviews: []*model.ReviewInfo{{ReviewID: 1, OrderID: 1, SkuID: 1000, DeleteAt: &deleted}}}
+	uc, repo := newTestDefaultReviewUsecase(orders, products, reviews)
+
+	n, err := uc.RunOnce(ctx, 1, time.Minute)
+	if err != nil || n != 1 {
+		t.Fatalf("first run: want 1 order, got %d, err: %v", n, err)
+	}
+	if got := defaultReviewSkus(reviews, 1); len(got) != 1 || got[0] != 1001 {
+		t.Fatalf("order 1: want default review for sku 1001, got %v", got)
+	}
+	if len(repo.parked) != 0 {
+		t.Fatalf("want no parked orders, got %v", repo.parked)
+	}
+	first := repo.checkpoint
+	if first == "" {
+		t.Fatal("want checkpoint saved after first run")
+	}
+
+	n, err = uc.RunOnce(ctx, 1, time.Minute)
+	if err != nil || n != 1 {
+		t.Fatalf("second run: want 1 order, got %d, err: %v", n, err)
+	}
+	if got := defaultReviewSkus(reviews, 2); len(got) != 1 {
+		t.Fatalf("order 2: want 1 default review, got %v", got)
+	}
+	if repo.checkpoint == first {
+		t.Fatal("want checkpoint advanced after second run")
+	}
+
+	// 没有新的到期订单时检查点不变，评价期限内的订单不处理
+	checkpoint := repo.checkpoint
+	n, err = uc.RunOnce(ctx, 1, time.Minute)
+	if err != nil || n != 0 {
+		t.Fatalf("third run: want 0 orders, got %d, err: %v", n, err)
+	}
+	if repo.checkpoint != checkpoint {
+		t.Fatalf("want checkpoint %q unchanged, got %q", checkpoint, repo.checkpoint)
+	}
+	if got := defaultReviewSkus(reviews, 3); len(got) != 0 {
+		t.Fatalf("order 3: want no default review within review window, got %v", got)
+	}
+}
+
+func TestRunOnceParksFailedOrders(t *testing.T) {
+	ctx := context.Background()
+	due := time.Now().Add(-defaultReviewWindow - time.Hour)
+	orders := NewMemoryOrderClient(&Order{OrderID: 1, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: due,
+		Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}}})
+	products := &unavailableProductClient{MemoryProductClient: NewMemoryProductClient(&Sku{SkuID: 1001, SpuID: 2000}), skuID: 1000}
+	reviews := &memoryReviewRepo{}
+	uc, repo := newTestDefaultReviewUsecase(orders, products, reviews)
+
+	if _, err := uc.RunOnce(ctx, 10, time.Minute); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if repo.parked[1] != 1 {
+		t.Fatalf("want order parked once, got %v", repo.parked)
+	}
+	if repo.checkpoint == "" {
+		t.Fatal("want checkpoint advanced past the failed order")
+	}
+
+	// 新完成的订单照常处理，失败的订单在重试队列中重试
+	orders.Put(&Order{OrderID: 2, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: due.Add(time.Minute),
+		Items: []*OrderItem{{SkuID: 1001, SpuID: 2000}}})
+	if _, err := uc.RunOnce(ctx, 10, time.Minute); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if got := defaultReviewSkus(reviews, 2); len(got) != 1 {
+		t.Fatalf("order 2: want 1 default review, got %v", got)
+	}
+	if repo.parked[1] != 2 {
+		t.Fatalf("want order 1 failed twice, got %v", repo.parked)
+	}
+
+	for i := 2; i < maxDefaultReviewAttempts; i++ {
+		if _, err := uc.RunOnce(ctx, 10, time.Minute); err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	}
+	if _, ok := repo.parked[1]; ok {
+		t.Fatalf("want order removed from retry queue, got %v", repo.parked)
+	}
+	if _, ok := repo.dead[1]; !ok {
+		t.Fatal("want order dead-lettered")
+	}
+}
+
+func TestRunOnceRetriesParkedOrders(t *testing.T) {
+	ctx := context.Background()
+	due := time.Now().Add(-defaultReviewWindow - time.Hour)
+	orders := NewMemoryOrderClient(&Order{OrderID: 1, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: due,
+		Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}}})
+	products := &unavailableProductClient{MemoryProductClient: NewMemoryProductClient(&Sku{SkuID: 1000, SpuID: 2000}), skuID: 1000}
+	reviews := &memoryReviewRepo{}
+	uc, repo := newTestDefaultReviewUsecase(orders, products, reviews)
+	// 重试队列中已不存在的订单直接移出
+	repo.parked[404] = 1
+
+	if _, err := uc.RunOnce(ctx, 10, time.Minute); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	products.skuID = 0
+	if _, err := uc.RunOnce(ctx, 10, time.Minute); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(repo.parked) != 0 {
+		t.Fatalf("want retry queue empty, got %v", repo.parked)
+	}
+	if got := defaultReviewSkus(reviews, 1); len(got) != 1 {
+		t.Fatalf("order 1: want 1 default review after retry, got %v", got)
+	}
+}
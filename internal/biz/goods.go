@@ -0,0 +1,93 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+)
+
+// 商品快照当前版本，快照结构变化时递增，读取时按版本兼容
+const GoodsSnapshotVersion = 1
+
+// SKU规格属性，如颜色、尺码
+type SkuAttr struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// 商品SKU信息
+type Sku struct {
+	SkuID int64
+	SpuID int64
+	Title string
+	Attrs []*SkuAttr
+	Image string
+	Price int64 // 单位：分
+}
+
+// GoodsSnapshot 评论时的商品快照，商品信息变更后评论仍按快照展示
+type GoodsSnapshot struct {
+	Version   int        `json:"version"`
+	SkuID     int64      `json:"sku_id,string"`
+	SpuID     int64      `json:"spu_id,string"`
+	Title     string     `json:"title"`
+	Attrs     []*SkuAttr `json:"attrs"`
+	Image     string     `json:"image"`
+	Price     int64      `json:"price"`
+	CaptureAt time.Time  `json:"capture_at"`
+}
+
+// ErrSkuNotFound 商品不存在
+var ErrSkuNotFound = errors.New("sku not found")
+
+// ProductClient 商品服务客户端，商品不存在时返回ErrSkuNotFound
+type ProductClient interface {
+	GetSku(ctx context.Context, skuID int64) (*Sku, error)
+}
+
+// ParseGoodsSnapshot 解析评论中的商品快照，未保存快照时返回nil
+func ParseGoodsSnapshot(s string) (*GoodsSnapshot, error) {
+	if s == "" {
+		return nil, nil
+	}
+	snapshot := &GoodsSnapshot{}
+	if err := json.Unmarshal([]byte(s), snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// captureGoodsSnapshot 调用商品服务获取SKU信息，生成商品快照写入评论
+func (uc *ReviewUsecase) captureGoodsSnapshot(ctx context.Context, r *model.ReviewInfo) error {
+	sku, err := uc.productClient.GetSku(ctx, r.SkuID)
+	if errors.Is(err, ErrSkuNotFound) {
+		return v1.ErrorSkuNotFound("商品不存在")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("商品sku:%d查询失败, err:%v", r.SkuID, err)
+		return v1.ErrorProductServiceErr("商品查询失败")
+	}
+	if sku.SpuID != r.SpuID {
+		uc.log.WithContext(ctx).Warnf("商品sku:%d不属于spu:%d", r.SkuID, r.SpuID)
+		return v1.ErrorParamErr("商品信息不匹配")
+	}
+	data, err := json.Marshal(&GoodsSnapshot{
+		Version:   GoodsSnapshotVersion,
+		SkuID:     sku.SkuID,
+		SpuID:     sku.SpuID,
+		Title:     sku.Title,
+		Attrs:     sku.Attrs,
+		Image:     sku.Image,
+		Price:     sku.Price,
+		CaptureAt: time.Now(),
+	})
+	if err != nil {
+		return v1.ErrorParamErr("商品快照生成失败")
+	}
+	r.GoodsSnapshoot = string(data)
+	return nil
+}
@@ -0,0 +1,66 @@
+package biz
+
+import (
+	"context"
+	"testing"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestCaptureGoodsSnapshot(t *testing.T) {
+	products := NewMemoryProductClient(&Sku{
+		SkuID: 1000,
+		SpuID: 2000,
+		Title: "纯棉T恤",
+		Attrs: []*SkuAttr{{Name: "颜色", Value: "白色"}, {Name: "尺码", Value: "L"}},
+		Image: "https://img.example.com/1000.jpg",
+		Price: 9900,
+	})
+	uc := &ReviewUsecase{productClient: products, log: log.NewHelper(log.DefaultLogger)}
+
+	t.Run("ok", func(t *testing.T) {
+		r := &model.ReviewInfo{SkuID: 1000, SpuID: 2000}
+		if err := uc.captureGoodsSnapshot(context.Background(), r); err != nil {
+			t.Fatalf("want nil, got %v", err)
+		}
+		snapshot, err := ParseGoodsSnapshot(r.GoodsSnapshoot)
+		if err != nil {
+			t.Fatalf("parse snapshot: %v", err)
+		}
+		if snapshot.Version != GoodsSnapshotVersion || snapshot.Title != "纯棉T恤" || snapshot.Price != 9900 ||
+			len(snapshot.Attrs) != 2 || snapshot.CaptureAt.IsZero() {
+			t.Fatalf("unexpected snapshot: %+v", snapshot)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		review *model.ReviewInfo
+		want   *errors.Error
+	}{
+		{"sku not found", &model.ReviewInfo{SkuID: 1001, SpuID: 2000}, v1.ErrorSkuNotFound("")},
+		{"spu mismatch", &model.ReviewInfo{SkuID: 1000, SpuID: 2001}, v1.ErrorParamErr("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.captureGoodsSnapshot(context.Background(), tt.review)
+			if got := errors.Reason(err); got != tt.want.Reason {
+				t.Fatalf("want reason %s, got %v", tt.want.Reason, err)
+			}
+			if tt.review.GoodsSnapshoot != "" {
+				t.Fatalf("snapshot should not be set on error")
+			}
+		})
+	}
+}
+
+func TestParseGoodsSnapshotEmpty(t *testing.T) {
+	snapshot, err := ParseGoodsSnapshot("")
+	if err != nil || snapshot != nil {
+		t.Fatalf("want nil snapshot, got %+v, %v", snapshot, err)
+	}
+}
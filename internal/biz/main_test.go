@@ -0,0 +1,16 @@
+package biz
+
+import (
+	"os"
+	"testing"
+
+	"review-service/internal/conf"
+	"review-service/pkg/snowflake"
+)
+
+func TestMain(m *testing.M) {
+	if err := snowflake.NewSnowFlake(&conf.SnowFlake{StartTime: "2025-10-24T00:00:00Z", MachineId: 1}); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
@@ -0,0 +1,97 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+)
+
+// 默认评论期限，订单完成后多久内可以评论
+const defaultReviewWindow = 30 * 24 * time.Hour
+
+// 订单状态
+type OrderStatus int32
+
+const (
+	OrderStatusUnknown   OrderStatus = iota // 未知
+	OrderStatusUnpaid                       // 待支付
+	OrderStatusPaid                         // 已支付
+	OrderStatusShipped                      // 已发货
+	OrderStatusCompleted                    // 已完成
+	OrderStatusCanceled                     // 已取消
+)
+
+// 订单商品行
+type OrderItem struct {
+	SkuID int64
+	SpuID int64
+}
+
+// 订单信息，只包含评论校验需要的字段
+type Order struct {
+	OrderID    int64
+	UserID     int64
+	StoreID    int64
+	Status     OrderStatus
+	CompleteAt time.Time
+	Items      []*OrderItem
+}
+
+// hasSku 订单中是否包含该SKU
+func (o *Order) hasSku(skuID int64) bool {
+	for _, item := range o.Items {
+		if item.SkuID == skuID {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrOrderNotFound 订单不存在
+var ErrOrderNotFound = errors.New("order not found")
+
+// OrderClient 订单服务客户端，订单不存在时返回ErrOrderNotFound
+type OrderClient interface {
+	GetOrder(ctx context.Context, orderID int64) (*Order, error)
+	// ListCompletedOrders 按完成时间顺序分页拉取completedBefore之前完成的订单，cursor为空表示从头开始，
+	// 返回的nextCursor为空表示没有更多数据
+	ListCompletedOrders(ctx context.Context, cursor string, completedBefore time.Time, size int) ([]*Order, string, error)
+}
+
+// checkOrderReviewable 校验订单是否可以评论：订单存在、属于该用户和店铺、已完成、在评论期限内且包含评论的商品，
+// reviews需属于同一订单
+func (uc *ReviewUsecase) checkOrderReviewable(ctx context.Context, reviews ...*model.ReviewInfo) error {
+	r := reviews[0]
+	order, err := uc.orderClient.GetOrder(ctx, r.OrderID)
+	if errors.Is(err, ErrOrderNotFound) {
+		return v1.ErrorOrderNotFound("订单不存在")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("订单id:%d查询失败, err:%v", r.OrderID, err)
+		return v1.ErrorOrderServiceErr("订单查询失败")
+	}
+	if order.UserID != r.UserID {
+		uc.log.WithContext(ctx).Warnf("用户id:%d无权限评论订单id:%d", r.UserID, r.OrderID)
+		return v1.ErrorOrderUserMismatch("订单不属于当前用户")
+	}
+	if order.StoreID != r.StoreID {
+		uc.log.WithContext(ctx).Warnf("订单id:%d不属于店铺id:%d", r.OrderID, r.StoreID)
+		return v1.ErrorOrderStoreMismatch("订单不属于该店铺")
+	}
+	if order.Status != OrderStatusCompleted {
+		return v1.ErrorOrderNotCompleted("订单未完成，不能评论")
+	}
+	if time.Since(order.CompleteAt) > uc.reviewWindow {
+		return v1.ErrorReviewWindowExpired("已超过评论期限")
+	}
+	for _, review := range reviews {
+		if !order.hasSku(review.SkuID) {
+			uc.log.WithContext(ctx).Warnf("订单id:%d不包含商品sku:%d", review.OrderID, review.SkuID)
+			return v1.ErrorOrderSkuMismatch("订单中不包含该商品")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryOrderClient 内存版订单服务客户端，用于测试
+type MemoryOrderClient struct {
+	mu     sync.RWMutex
+	orders map[int64]*Order
+}
+
+// NewMemoryOrderClient .
+func NewMemoryOrderClient(orders ...*Order) *MemoryOrderClient {
+	c := &MemoryOrderClient{orders: make(map[int64]*Order, len(orders))}
+	for _, order := range orders {
+		c.orders[order.OrderID] = order
+	}
+	return c
+}
+
+// Put 添加或覆盖订单
+func (c *MemoryOrderClient) Put(order *Order) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.orders[order.OrderID] = order
+}
+
+// GetOrder 返回订单的副本，不存在时返回ErrOrderNotFound
+func (c *MemoryOrderClient) GetOrder(ctx context.Context, orderID int64) (*Order, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	order, ok := c.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	o := *order
+	o.Items = append([]*OrderItem(nil), order.Items...)
+	return &o, nil
+}
+
+// ListCompletedOrders 按完成时间和订单ID排序分页，游标为本页最后一个订单的完成时间和订单ID，
+// 没有新订单时返回空游标，之后完成的订单排在游标之后，可以从游标继续拉取
+func (c *MemoryOrderClient) ListCompletedOrders(ctx context.Context, cursor string, completedBefore time.Time, size int) ([]*Order, string, error) {
+	var afterNano, afterID int64
+	if cursor != "" {
+		if _, err := fmt.Sscanf(cursor, "%d:%d", &afterNano, &afterID); err != nil {
+			return nil, "", err
+		}
+	}
+	c.mu.RLock()
+	completed := make([]*Order, 0, len(c.orders))
+	for _, order := range c.orders {
+		if order.Status != OrderStatusCompleted || !order.CompleteAt.Before(completedBefore) {
+			continue
+		}
+		nano := order.CompleteAt.UnixNano()
+		if cursor != "" && (nano < afterNano || nano == afterNano && order.OrderID <= afterID) {
+			continue
+		}
+		o := *order
+		o.Items = append([]*OrderItem(nil), order.Items...)
+		completed = append(completed, &o)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(completed, func(i, j int) bool {
+		if !completed[i].CompleteAt.Equal(completed[j].CompleteAt) {
+			return completed[i].CompleteAt.Before(completed[j].CompleteAt)
+		}
+		return completed[i].OrderID < completed[j].OrderID
+	})
+	if len(completed) == 0 {
+		return nil, "", nil
+	}
+	if len(completed) > size {
+		completed = completed[:size]
+	}
+	last := completed[len(completed)-1]
+	return completed, fmt.Sprintf("%d:%d", last.CompleteAt.UnixNano(), last.OrderID), nil
+}
@@ -0,0 +1,59 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestCheckOrderReviewable(t *testing.T) {
+	now := time.Now()
+	orders := NewMemoryOrderClient(
+		&Order{OrderID: 1, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: now.Add(-time.Hour),
+			Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}, {SkuID: 1001, SpuID: 2000}}},
+		&Order{OrderID: 2, UserID: 10, StoreID: 100, Status: OrderStatusShipped,
+			Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}}},
+		&Order{OrderID: 3, UserID: 10, StoreID: 100, Status: OrderStatusCompleted, CompleteAt: now.Add(-48 * time.Hour),
+			Items: []*OrderItem{{SkuID: 1000, SpuID: 2000}}},
+	)
+	uc := &ReviewUsecase{orderClient: orders, reviewWindow: 24 * time.Hour, log: log.NewHelper(log.DefaultLogger)}
+
+	review := func(orderID, userID, storeID, skuID int64) *model.ReviewInfo {
+		return &model.ReviewInfo{OrderID: orderID, UserID: userID, StoreID: storeID, SkuID: skuID, SpuID: 2000}
+	}
+	tests := []struct {
+		name    string
+		reviews []*model.ReviewInfo
+		want    *errors.Error
+	}{
+		{"ok", []*model.ReviewInfo{review(1, 10, 100, 1000)}, nil},
+		{"batch ok", []*model.ReviewInfo{review(1, 10, 100, 1000), review(1, 10, 100, 1001)}, nil},
+		{"order not found", []*model.ReviewInfo{review(9, 10, 100, 1000)}, v1.ErrorOrderNotFound("")},
+		{"user mismatch", []*model.ReviewInfo{review(1, 11, 100, 1000)}, v1.ErrorOrderUserMismatch("")},
+		{"store mismatch", []*model.ReviewInfo{review(1, 10, 101, 1000)}, v1.ErrorOrderStoreMismatch("")},
+		{"not completed", []*model.ReviewInfo{review(2, 10, 100, 1000)}, v1.ErrorOrderNotCompleted("")},
+		{"window expired", []*model.ReviewInfo{review(3, 10, 100, 1000)}, v1.ErrorReviewWindowExpired("")},
+		{"sku mismatch", []*model.ReviewInfo{review(1, 10, 100, 1002)}, v1.ErrorOrderSkuMismatch("")},
+		{"batch sku mismatch", []*model.ReviewInfo{review(1, 10, 100, 1000), review(1, 10, 100, 1002)}, v1.ErrorOrderSkuMismatch("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.checkOrderReviewable(context.Background(), tt.reviews...)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("want nil, got %v", err)
+				}
+				return
+			}
+			if got := errors.Reason(err); got != tt.want.Reason {
+				t.Fatalf("want reason %s, got %v", tt.want.Reason, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package biz
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryProductClient 内存版商品服务客户端，用于测试
+type MemoryProductClient struct {
+	mu   sync.RWMutex
+	skus map[int64]*Sku
+}
+
+// NewMemoryProductClient .
+func NewMemoryProductClient(skus ...*Sku) *MemoryProductClient {
+	c := &MemoryProductClient{skus: make(map[int64]*Sku, len(skus))}
+	for _, sku := range skus {
+		c.skus[sku.SkuID] = sku
+	}
+	return c
+}
+
+// Put 添加或覆盖SKU
+func (c *MemoryProductClient) Put(sku *Sku) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.skus[sku.SkuID] = sku
+}
+
+// GetSku 返回SKU的副本，不存在时返回ErrSkuNotFound
+func (c *MemoryProductClient) GetSku(ctx context.Context, skuID int64) (*Sku, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	sku, ok := c.skus[skuID]
+	if !ok {
+		return nil, ErrSkuNotFound
+	}
+	s := *sku
+	s.Attrs = append([]*SkuAttr(nil), sku.Attrs...)
+	return &s, nil
+}
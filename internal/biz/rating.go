@@ -0,0 +1,32 @@
+package biz
+
+import (
+	"context"
+
+	v1 "review-service/api/review/v1"
+)
+
+// StoreRatingSummary 店铺评分汇总，来自店铺评分统计表，只统计审核通过的评论
+type StoreRatingSummary struct {
+	StoreID         int64    `json:"store_id,string"`
+	Total           int64    `json:"total"`
+	AvgScore        float64  `json:"avg_score"`
+	AvgServiceScore float64  `json:"avg_service_score"`
+	AvgExpressScore float64  `json:"avg_express_score"`
+	ScoreCounts     [5]int64 `json:"score_counts"` // 1~5星的评论数，下标0为1星
+	MediaRate       float64  `json:"media_rate"`   // 有图或视频的评论占比，百分比
+	ReplyRate       float64  `json:"reply_rate"`   // 商家已回复的评论占比，百分比
+}
+
+// 获取店铺评分汇总
+func (uc *ReviewUsecase) GetStoreRatingSummary(ctx context.Context, storeID int64) (*StoreRatingSummary, error) {
+	if storeID <= 0 {
+		return nil, v1.ErrorParamErr("店铺ID不合法")
+	}
+	summary, err := uc.repo.GetStoreRatingSummary(ctx, storeID)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("店铺id:%d评分汇总查询失败, err:%v", storeID, err)
+		return nil, v1.ErrorGormBadErr("评分汇总查询失败")
+	}
+	return summary, nil
+}
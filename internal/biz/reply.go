@@ -0,0 +1,90 @@
+package biz
+
+import (
+	"context"
+	"errors"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+
+	"gorm.io/gorm"
+)
+
+// 按店铺查询商家回复列表参数，Cursor为上一页最后一条回复ID，0表示第一页
+type ListRepliesByStoreParam struct {
+	StoreID int64
+	Cursor  int64
+	Size    int32
+}
+
+// 商家修改回复
+func (uc *ReviewUsecase) UpdateReply(ctx context.Context, reply *ReviewReply) error {
+	existing, err := uc.getStoreReply(ctx, reply.ReplyID, reply.StoreID)
+	if err != nil {
+		return err
+	}
+	err = uc.repo.UpdateReply(ctx, existing, reply)
+	if errors.Is(err, ErrReviewVersionConflict) {
+		return v1.ErrorReviewVersionConflict("回复已被修改，请刷新后重试")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("回复id:%d修改失败, err:%v", reply.ReplyID, err)
+		return v1.ErrorGormBadErr("回复修改失败")
+	}
+	return nil
+}
+
+// 商家删除回复，删除后评论恢复为未回复，可以重新回复
+func (uc *ReviewUsecase) DeleteReply(ctx context.Context, replyID int64, storeID int64) error {
+	reply, err := uc.getStoreReply(ctx, replyID, storeID)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.DeleteReply(ctx, reply); err != nil {
+		uc.log.WithContext(ctx).Errorf("回复id:%d删除失败, err:%v", replyID, err)
+		return v1.ErrorGormBadErr("回复删除失败")
+	}
+	return nil
+}
+
+// getStoreReply 查询回复并校验是否属于当前商家
+func (uc *ReviewUsecase) getStoreReply(ctx context.Context, replyID int64, storeID int64) (*model.ReviewReplyInfo, error) {
+	reply, err := uc.repo.GetReplyByReplyID(ctx, replyID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, v1.ErrorGormBadErr("回复不存在")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("回复id:%d查询失败, err:%v", replyID, err)
+		return nil, v1.ErrorGormBadErr("回复查询失败")
+	}
+	// 不能水平越权【A商家不能修改B商家的回复】
+	if reply.StoreID != storeID {
+		uc.log.WithContext(ctx).Warnf("商家id:%d无权限修改回复id:%d", storeID, replyID)
+		return nil, v1.ErrorReviewUnauthorizedAccess("水平越权")
+	}
+	return reply, nil
+}
+
+// 商家后台按回复ID倒序查询回复及对应的评论，返回列表和下一页游标，游标为0表示没有更多数据
+func (uc *ReviewUsecase) ListRepliesByStore(ctx context.Context, param *ListRepliesByStoreParam) ([]*ReviewDetail, int64, error) {
+	if param.Size <= 0 {
+		param.Size = 10
+	}
+	if param.Size > MaxBatchGetReviews {
+		param.Size = MaxBatchGetReviews
+	}
+	// 多查一条用于判断是否还有下一页
+	size := param.Size
+	param.Size++
+	details, err := uc.repo.ListRepliesByStoreID(ctx, param)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("商家id:%d回复列表查询失败, err:%v", param.StoreID, err)
+		return nil, 0, v1.ErrorGormBadErr("回复列表查询失败")
+	}
+	var nextCursor int64
+	if len(details) > int(size) {
+		details = details[:size]
+		nextCursor = details[size-1].Reply.ReplyID
+	}
+	return details, nextCursor, nil
+}
@@ -4,13 +4,24 @@ import (
 	"context"
 	"errors"
 	v1 "review-service/api/review/v1"
+	"review-service/internal/conf"
 	"review-service/internal/data/model"
 	"review-service/pkg/snowflake"
+	"strings"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"gorm.io/gorm"
 )
 
+// 评论状态
+const (
+	ReviewStatusPending  int32 = 10 // 待审核
+	ReviewStatusApproved int32 = 20 // 审核通过
+	ReviewStatusRejected int32 = 30 // 审核不通过
+	ReviewStatusHidden   int32 = 40 // 隐藏
+)
+
 // 商家回复
 type ReviewReply struct {
 	ReplyID   int64
@@ -21,49 +32,272 @@ type ReviewReply struct {
 	Content   string
 }
 
+// 评论详情，包含商家回复、追评和最近一次申诉
+type ReviewDetail struct {
+	Review *model.ReviewInfo
+	Reply  *model.ReviewReplyInfo
+	Append *model.ReviewAppendInfo
+	Appeal *model.ReviewAppealInfo
+}
+
+// 评论列表排序方式
+type ReviewSortBy int32
+
+const (
+	ReviewSortNewest      ReviewSortBy = iota // 最新
+	ReviewSortScoreDesc                       // 评分从高到低
+	ReviewSortScoreAsc                        // 评分从低到高
+	ReviewSortMostHelpful                     // 最有帮助，有图优先、默认评价靠后、高分优先
+)
+
+// 评论列表筛选条件，零值表示不过滤
+type ReviewListFilter struct {
+	MinScore    int32    // 最低评分
+	MaxScore    int32    // 最高评分
+	HasMedia    bool     // 只看有图/视频
+	HasReply    bool     // 只看商家已回复
+	HideDefault bool     // 不看系统默认评价
+	Tags        []string // 包含全部标签
+	StartTime   time.Time
+	EndTime     time.Time
+	SortBy      ReviewSortBy
+}
+
+// 评论搜索参数，StoreID和SpuID至少指定一个
+type ReviewSearchParam struct {
+	Keyword string
+	StoreID int64
+	SpuID   int64
+}
+
+// 评论搜索结果，Highlights为命中关键词的高亮片段
+type ReviewSearchHit struct {
+	Review     *ReviewInfo
+	Highlights []string
+}
+
+// 评论列表分页参数，PageToken不为空时使用游标翻页，忽略Offset
+type ReviewPage struct {
+	Offset    int32
+	Size      int32
+	PageToken string
+}
+
+// 评论列表分页结果，NextPageToken为空表示没有下一页
+type ReviewListResult struct {
+	List          []*ReviewInfo `json:"list"`
+	NextPageToken string        `json:"next_page_token"`
+	Total         int64         `json:"total"`
+}
+
+// ErrInvalidPageToken 分页游标无法解析
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrReviewRepeated 订单商品行已存在评论，包括已删除的评论
+var ErrReviewRepeated = errors.New("review repeated")
+
+// ErrReviewVersionConflict 评论已被修改，乐观锁版本不一致
+var ErrReviewVersionConflict = errors.New("review version conflict")
+
+// ErrReviewAppended 评论已追评，包括已删除的追评
+var ErrReviewAppended = errors.New("review appended")
+
+// ErrReviewStatusChanged 评论状态已被并发修改，按原状态更新失败
+var ErrReviewStatusChanged = errors.New("review status changed")
+
+// ErrReviewHasReply 评论已被回复，并发回复时只有一个成功
+var ErrReviewHasReply = errors.New("review has reply")
+
+// 默认评论修改、删除期限
+const defaultEditWindow = 7 * 24 * time.Hour
+
+// es默认max_result_window，from+size超过后只能使用游标翻页
+const maxResultWindow = 10000
+
+// 批量查询评论的最大数量
+const MaxBatchGetReviews = 50
+
+// 批量创建评论的最大数量
+const MaxBatchCreateReviews = 20
+
+// 按用户查询评论列表参数，Cursor为上一页最后一条评论ID，0表示第一页
+type ListReviewsByUserParam struct {
+	UserID   int64
+	Cursor   int64
+	Size     int32
+	Statuses []int32 // 为空时不过滤状态
+}
+
+// 运营审核评论
+type AuditReview struct {
+	ReviewID  int64
+	Status    int32
+	OpReason  string
+	OpRemarks string
+	OpUser    string
+}
+
+// 用户修改评论，Version为用户读取评论时的版本号
+type UpdateReview struct {
+	ReviewID     int64
+	UserID       int64
+	Version      int32
+	Content      string
+	PicInfo      string
+	VideoInfo    string
+	Score        int32
+	ServiceScore int32
+	ExpressScore int32
+	Anonymous    int32
+}
+
+// ReviewHasMedia 评论是否包含图片或视频
+func ReviewHasMedia(picInfo, videoInfo string) int32 {
+	if picInfo != "" || videoInfo != "" {
+		return 1
+	}
+	return 0
+}
+
 // ReviewRepo is a Review repo.
 type ReviewRepo interface {
 	SaveReview(context.Context, *model.ReviewInfo) (int64, error) // C端
-	GetReviewByOrderID(context.Context, int64) (*model.ReviewInfo, error)
-	ReplyReview(context.Context, *ReviewReply) (int64, error) // B端
+	BatchSaveReviews(context.Context, []*model.ReviewInfo) ([]int64, error)
+	GetReviewByOrderID(context.Context, int64) ([]*model.ReviewInfo, error)
+	ListReviewedSkuIDs(context.Context, int64) ([]int64, error) // 包括已删除的评论
+	ReplyReview(context.Context, *ReviewReply) (int64, error)   // B端
 	GetReviewByReviewID(context.Context, int64) (*model.ReviewInfo, error)
-	GetReviewListByStoreID(context.Context, int64, int32, int32) ([]*ReviewInfo, error)
-	GetSingleflightReviewListByStoreID(context.Context, int64, int32, int32) ([]*ReviewInfo, error)
+	GetReviewListByStoreID(context.Context, int64, *ReviewListFilter, *ReviewPage) (*ReviewListResult, error)
+	GetSingleflightReviewListByStoreID(context.Context, int64, *ReviewListFilter, *ReviewPage) (*ReviewListResult, error)
+	AuditReview(context.Context, *AuditReview, int32) error // O端
+	GetReviewDetail(context.Context, int64) (*ReviewDetail, error)
+	BatchGetReviewDetails(context.Context, []int64) ([]*ReviewDetail, error)
+	ListReviewsByUserID(context.Context, *ListReviewsByUserParam) ([]*ReviewDetail, error)
+	ListReviewsBySpuID(context.Context, int64, int64, *ReviewPage) (*ReviewListResult, error)
+	SearchReviews(context.Context, *ReviewSearchParam, int32, int32) ([]*ReviewSearchHit, int64, error)
+	GetStoreRatingSummary(context.Context, int64) (*StoreRatingSummary, error)
+	SaveReviewAppend(context.Context, *model.ReviewAppendInfo) (int64, error)
+	GetReviewAppendByReviewID(context.Context, int64) (*model.ReviewAppendInfo, error)
+	GetReviewAppendByAppendID(context.Context, int64) (*model.ReviewAppendInfo, error)
+	AuditReviewAppend(context.Context, int64, *AuditReviewAppend, int32) error
+	UpdateReview(context.Context, *model.ReviewInfo, *UpdateReview) (int32, error)
+	DeleteReview(context.Context, *model.ReviewInfo) error
+	GetReplyByReplyID(context.Context, int64) (*model.ReviewReplyInfo, error)
+	UpdateReply(context.Context, *model.ReviewReplyInfo, *ReviewReply) error
+	DeleteReply(context.Context, *model.ReviewReplyInfo) error
+	ListRepliesByStoreID(context.Context, *ListRepliesByStoreParam) ([]*ReviewDetail, error)
 }
 
 // ReviewUsecase is a Review usecase.
 type ReviewUsecase struct {
-	repo ReviewRepo
-	log  *log.Helper
+	repo          ReviewRepo
+	orderClient   OrderClient
+	productClient ProductClient
+	appendWindow  time.Duration
+	editWindow    time.Duration
+	reviewWindow  time.Duration
+	log           *log.Helper
 }
 
 // NewReviewUsecase new a Review usecase.
-func NewReviewUsecase(repo ReviewRepo, logger log.Logger) *ReviewUsecase {
-	return &ReviewUsecase{repo: repo, log: log.NewHelper(logger)}
+func NewReviewUsecase(c *conf.Review, repo ReviewRepo, orderClient OrderClient, productClient ProductClient, logger log.Logger) *ReviewUsecase {
+	uc := &ReviewUsecase{
+		repo:          repo,
+		orderClient:   orderClient,
+		productClient: productClient,
+		appendWindow:  defaultAppendWindow,
+		editWindow:    defaultEditWindow,
+		reviewWindow:  defaultReviewWindow,
+		log:           log.NewHelper(logger),
+	}
+	if c.GetAppendWindow() != nil {
+		uc.appendWindow = c.GetAppendWindow().AsDuration()
+	}
+	if c.GetEditWindow() != nil {
+		uc.editWindow = c.GetEditWindow().AsDuration()
+	}
+	if c.GetReviewWindow() != nil {
+		uc.reviewWindow = c.GetReviewWindow().AsDuration()
+	}
+	return uc
 }
 
 // 创建评论
 func (uc *ReviewUsecase) SaveReview(ctx context.Context, r *model.ReviewInfo) (int64, error) {
-	//	1. 业务校验，同一个订单只能创建一次评论
-	review, err := uc.repo.GetReviewByOrderID(ctx, r.OrderID)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		uc.log.WithContext(ctx).Warnf("订单id:%d查询失败", r.OrderID)
-		return 0, v1.ErrorGormBadErr("订单查询失败")
+	if err := uc.prepareReviews(ctx, r); err != nil {
+		return 0, err
+	}
+	reviewID, err := uc.repo.SaveReview(ctx, r)
+	if errors.Is(err, ErrReviewRepeated) {
+		return 0, v1.ErrorReviewRepeatedErr("订单商品已存在评论")
+	}
+	return reviewID, err
+}
+
+// 批量创建同一订单多个商品的评论，全部成功或全部失败
+func (uc *ReviewUsecase) BatchSaveReviews(ctx context.Context, reviews []*model.ReviewInfo) ([]int64, error) {
+	if len(reviews) == 0 {
+		return nil, v1.ErrorParamErr("评论不能为空")
+	}
+	if len(reviews) > MaxBatchCreateReviews {
+		return nil, v1.ErrorParamErr("一次最多创建%d条评论", MaxBatchCreateReviews)
+	}
+	seen := make(map[int64]struct{}, len(reviews))
+	for _, r := range reviews {
+		if r.OrderID != reviews[0].OrderID || r.UserID != reviews[0].UserID || r.StoreID != reviews[0].StoreID {
+			return nil, v1.ErrorParamErr("批量评论必须属于同一订单")
+		}
+		if _, ok := seen[r.SkuID]; ok {
+			return nil, v1.ErrorParamErr("同一商品只能评论一次")
+		}
+		seen[r.SkuID] = struct{}{}
 	}
-	if review != nil {
-		uc.log.WithContext(ctx).Warnf("订单id:%d已存在评论，不能重复创建", r.OrderID)
-		return 0, v1.ErrorReviewRepeatedErr("订单已存在评论")
+	if err := uc.prepareReviews(ctx, reviews...); err != nil {
+		return nil, err
 	}
+	reviewIDs, err := uc.repo.BatchSaveReviews(ctx, reviews)
+	if errors.Is(err, ErrReviewRepeated) {
+		return nil, v1.ErrorReviewRepeatedErr("订单商品已存在评论")
+	}
+	return reviewIDs, err
+}
 
-	// 2. reviewID根据雪花算法生成分布式唯一ID
-	r.ReviewID = snowflake.GenID()
+// prepareReviews 创建评论前的校验和数据准备，reviews需属于同一订单
+func (uc *ReviewUsecase) prepareReviews(ctx context.Context, reviews ...*model.ReviewInfo) error {
+	// 1. 业务校验，订单的每个商品只能创建一次评论
+	orderID := reviews[0].OrderID
+	existing, err := uc.repo.GetReviewByOrderID(ctx, orderID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("订单id:%d查询失败", orderID)
+		return v1.ErrorGormBadErr("订单查询失败")
+	}
+	reviewed := make(map[int64]struct{}, len(existing))
+	for _, review := range existing {
+		reviewed[review.SkuID] = struct{}{}
+	}
+	for _, r := range reviews {
+		if _, ok := reviewed[r.SkuID]; ok {
+			uc.log.WithContext(ctx).Warnf("订单id:%d商品sku:%d已存在评论，不能重复创建", orderID, r.SkuID)
+			return v1.ErrorReviewRepeatedErr("订单商品已存在评论")
+		}
+	}
 
-	// 3. 查看订单信息和商品快照
-	// 调用订单相关的rpc接口获取订单信息
-	// TODO: 此处省略调用订单服务的代码
+	// 2. 调用订单服务校验评论资格
+	if err := uc.checkOrderReviewable(ctx, reviews...); err != nil {
+		return err
+	}
 
-	// 4. 评论入库
-	return uc.repo.SaveReview(ctx, r)
+	for _, r := range reviews {
+		// 3. 调用商品服务保存商品快照
+		if err := uc.captureGoodsSnapshot(ctx, r); err != nil {
+			return err
+		}
+		// 4. 有图/视频标记用于筛选、排序和统计
+		r.HasMedia = ReviewHasMedia(r.PicInfo, r.VideoInfo)
+		// 5. reviewID根据雪花算法生成分布式唯一ID
+		r.ReviewID = snowflake.GenID()
+	}
+	return nil
 }
 
 // 回复评论
@@ -92,19 +326,244 @@ func (uc *ReviewUsecase) ReplyReview(ctx context.Context, reply *ReviewReply) (i
 
 	// 3. 回复入库
 	reply.ReplyID = snowflake.GenID()
-	return uc.repo.ReplyReview(ctx, reply)
+	replyID, err := uc.repo.ReplyReview(ctx, reply)
+	if errors.Is(err, ErrReviewHasReply) {
+		return 0, v1.ErrorReviewHasReplyErr("评论id:%d已回复", reply.ReviewID)
+	}
+	return replyID, err
+}
+
+// 用户修改评论，修改后重新进入待审核，返回新的版本号
+func (uc *ReviewUsecase) UpdateReview(ctx context.Context, u *UpdateReview) (int32, error) {
+	review, err := uc.getOwnReview(ctx, u.ReviewID, u.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if review.Version != u.Version {
+		return 0, v1.ErrorReviewVersionConflict("评论已被修改，请刷新后重试")
+	}
+	// 修改后重新进入待审核，被运营隐藏的评论不能通过修改绕过隐藏
+	if review.Status == ReviewStatusHidden {
+		return 0, v1.ErrorReviewStatusTransitionErr("评论已被隐藏，不能修改")
+	}
+	version, err := uc.repo.UpdateReview(ctx, review, u)
+	if errors.Is(err, ErrReviewVersionConflict) {
+		return 0, v1.ErrorReviewVersionConflict("评论已被修改，请刷新后重试")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("评论id:%d修改失败, err:%v", u.ReviewID, err)
+		return 0, v1.ErrorGormBadErr("评论修改失败")
+	}
+	return version, nil
+}
+
+// 用户删除评论，逻辑删除
+func (uc *ReviewUsecase) DeleteReview(ctx context.Context, reviewID int64, userID int64) error {
+	review, err := uc.getOwnReview(ctx, reviewID, userID)
+	if err != nil {
+		return err
+	}
+	err = uc.repo.DeleteReview(ctx, review)
+	if errors.Is(err, ErrReviewVersionConflict) {
+		return v1.ErrorReviewVersionConflict("评论已被修改，请刷新后重试")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("评论id:%d删除失败, err:%v", reviewID, err)
+		return v1.ErrorGormBadErr("评论删除失败")
+	}
+	return nil
+}
+
+// getOwnReview 查询用户自己的评论，并校验是否在修改期限内
+func (uc *ReviewUsecase) getOwnReview(ctx context.Context, reviewID int64, userID int64) (*model.ReviewInfo, error) {
+	review, err := uc.repo.GetReviewByReviewID(ctx, reviewID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, v1.ErrorGormBadErr("评论不存在")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("评论id:%d查询失败, err:%v", reviewID, err)
+		return nil, v1.ErrorGormBadErr("评论查询失败")
+	}
+	if review.UserID != userID {
+		uc.log.WithContext(ctx).Warnf("用户id:%d无权限修改评论id:%d", userID, reviewID)
+		return nil, v1.ErrorReviewUnauthorizedAccess("水平越权")
+	}
+	if time.Since(review.CreateAt) > uc.editWindow {
+		return nil, v1.ErrorParamErr("已超过评论修改期限")
+	}
+	return review, nil
 }
 
 // 根据店铺ID获取评论列表
-func (uc *ReviewUsecase) GetReviewListByStoreID(ctx context.Context, storeID int64, page int32, size int32) ([]*ReviewInfo, error) {
+func (uc *ReviewUsecase) GetReviewListByStoreID(ctx context.Context, storeID int64, filter *ReviewListFilter, page int32, size int32, pageToken string) (*ReviewListResult, error) {
 	// 业务逻辑校验
+	if filter == nil {
+		filter = &ReviewListFilter{}
+	}
+	if filter.MinScore > 0 && filter.MaxScore > 0 && filter.MinScore > filter.MaxScore {
+		return nil, v1.ErrorParamErr("最低评分不能大于最高评分")
+	}
+	if !filter.StartTime.IsZero() && !filter.EndTime.IsZero() && filter.StartTime.After(filter.EndTime) {
+		return nil, v1.ErrorParamErr("开始时间不能晚于结束时间")
+	}
+	reviewPage, err := newReviewPage(page, size, pageToken)
+	if err != nil {
+		return nil, err
+	}
+	// return uc.repo.GetReviewListByStoreID(ctx, storeID, filter, reviewPage)
+	result, err := uc.repo.GetSingleflightReviewListByStoreID(ctx, storeID, filter, reviewPage)
+	if errors.Is(err, ErrInvalidPageToken) {
+		return nil, v1.ErrorParamErr("分页游标不合法")
+	}
+	return result, err
+}
+
+// 根据商品SPU获取评论列表，skuID大于0时只查该SKU
+func (uc *ReviewUsecase) ListReviewsBySpu(ctx context.Context, spuID int64, skuID int64, page int32, size int32, pageToken string) (*ReviewListResult, error) {
+	reviewPage, err := newReviewPage(page, size, pageToken)
+	if err != nil {
+		return nil, err
+	}
+	result, err := uc.repo.ListReviewsBySpuID(ctx, spuID, skuID, reviewPage)
+	if errors.Is(err, ErrInvalidPageToken) {
+		return nil, v1.ErrorParamErr("分页游标不合法")
+	}
+	return result, err
+}
+
+// newReviewPage 校验分页参数，有游标时使用游标翻页，否则兼容page/size翻页
+func newReviewPage(page int32, size int32, pageToken string) (*ReviewPage, error) {
 	if page <= 0 {
 		page = 1
 	}
 	if size <= 0 {
 		size = 10
 	}
+	if pageToken != "" {
+		return &ReviewPage{Size: size, PageToken: pageToken}, nil
+	}
 	offset := (page - 1) * size
-	// return uc.repo.GetReviewListByStoreID(ctx, storeID, offset, size)
-	return uc.repo.GetSingleflightReviewListByStoreID(ctx, storeID, offset, size)
+	if offset+size > maxResultWindow {
+		return nil, v1.ErrorParamErr("翻页过深，请使用page_token翻页")
+	}
+	return &ReviewPage{Offset: offset, Size: size}, nil
+}
+
+// 按关键词搜索店铺或商品下审核通过的评论，返回命中列表和总数
+func (uc *ReviewUsecase) SearchReviews(ctx context.Context, param *ReviewSearchParam, page int32, size int32) ([]*ReviewSearchHit, int64, error) {
+	param.Keyword = strings.TrimSpace(param.Keyword)
+	if param.Keyword == "" {
+		return nil, 0, v1.ErrorParamErr("搜索关键词不能为空")
+	}
+	if param.StoreID <= 0 && param.SpuID <= 0 {
+		return nil, 0, v1.ErrorParamErr("店铺ID和商品ID至少指定一个")
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if size <= 0 {
+		size = 10
+	}
+	offset := (page - 1) * size
+	hits, total, err := uc.repo.SearchReviews(ctx, param, offset, size)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("搜索评论失败[keyword:%s], err:%v", param.Keyword, err)
+		return nil, 0, err
+	}
+	return hits, total, nil
+}
+
+// 运营审核评论
+func (uc *ReviewUsecase) AuditReview(ctx context.Context, audit *AuditReview) error {
+	// 1. 查询评论当前状态
+	review, err := uc.repo.GetReviewByReviewID(ctx, audit.ReviewID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		uc.log.WithContext(ctx).Errorf("评论id:%d查询失败, err:%v", audit.ReviewID, err)
+		return v1.ErrorGormBadErr("评论查询失败")
+	}
+	if review == nil {
+		uc.log.WithContext(ctx).Warnf("评论id:%d不存在，无法审核", audit.ReviewID)
+		return v1.ErrorGormBadErr("评论不存在，无法审核")
+	}
+
+	// 2. 校验状态流转是否合法
+	if !CanTransitReviewStatus(review.Status, audit.Status) {
+		uc.log.WithContext(ctx).Warnf("评论id:%d状态不能从%d流转到%d", audit.ReviewID, review.Status, audit.Status)
+		return v1.ErrorReviewStatusTransitionErr("评论状态不能从%d变更为%d", review.Status, audit.Status)
+	}
+
+	// 3. 更新评论状态和审核信息
+	err = uc.repo.AuditReview(ctx, audit, review.Status)
+	if errors.Is(err, ErrReviewStatusChanged) {
+		uc.log.WithContext(ctx).Warnf("评论id:%d状态已变更，审核失败", audit.ReviewID)
+		return v1.ErrorReviewStatusTransitionErr("评论状态已变更，请刷新后重试")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("评论id:%d审核失败, err:%v", audit.ReviewID, err)
+		return v1.ErrorGormBadErr("评论审核失败")
+	}
+	return nil
+}
+
+// 根据评论ID获取评论详情
+func (uc *ReviewUsecase) GetReview(ctx context.Context, reviewID int64) (*ReviewDetail, error) {
+	detail, err := uc.repo.GetReviewDetail(ctx, reviewID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, v1.ErrorGormBadErr("评论不存在")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("评论id:%d查询失败, err:%v", reviewID, err)
+		return nil, v1.ErrorGormBadErr("评论查询失败")
+	}
+	return detail, nil
+}
+
+// 批量获取评论详情，按请求顺序返回，不存在的评论会被忽略
+func (uc *ReviewUsecase) BatchGetReviews(ctx context.Context, reviewIDs []int64) ([]*ReviewDetail, error) {
+	// 去重
+	ids := make([]int64, 0, len(reviewIDs))
+	seen := make(map[int64]struct{}, len(reviewIDs))
+	for _, id := range reviewIDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return []*ReviewDetail{}, nil
+	}
+	if len(ids) > MaxBatchGetReviews {
+		return nil, v1.ErrorParamErr("一次最多查询%d条评论", MaxBatchGetReviews)
+	}
+	details, err := uc.repo.BatchGetReviewDetails(ctx, ids)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("批量查询评论失败, err:%v", err)
+		return nil, v1.ErrorGormBadErr("评论查询失败")
+	}
+	return details, nil
+}
+
+// 根据用户ID获取评论列表，返回评论列表和下一页游标，游标为0表示没有更多数据
+func (uc *ReviewUsecase) ListReviewsByUser(ctx context.Context, param *ListReviewsByUserParam) ([]*ReviewDetail, int64, error) {
+	if param.Size <= 0 {
+		param.Size = 10
+	}
+	if param.Size > MaxBatchGetReviews {
+		param.Size = MaxBatchGetReviews
+	}
+	// 多查一条用于判断是否还有下一页
+	size := param.Size
+	param.Size++
+	details, err := uc.repo.ListReviewsByUserID(ctx, param)
+	if err != nil {
+		uc.log.WithContext(ctx).Errorf("用户id:%d评论列表查询失败, err:%v", param.UserID, err)
+		return nil, 0, v1.ErrorGormBadErr("评论列表查询失败")
+	}
+	var nextCursor int64
+	if len(details) > int(size) {
+		details = details[:size]
+		nextCursor = details[size-1].Review.ReviewID
+	}
+	return details, nextCursor, nil
 }
@@ -0,0 +1,88 @@
+package biz
+
+import (
+	"context"
+	"sync"
+
+	"review-service/internal/data/model"
+
+	"gorm.io/gorm"
+)
+
+// memoryReviewRepo 内存版评论repo，用于测试，只实现测试用到的方法，其余方法调用时panic。
+// 与review_info的uk_order_sku一致，同一订单商品行只能有一条评论，已删除的评论也占用该商品行
+type memoryReviewRepo struct {
+	ReviewRepo
+	mu      sync.Mutex
+	reviews []*model.ReviewInfo
+}
+
+// GetReviewByOrderID 返回订单下未删除的评论
+func (r *memoryReviewRepo) GetReviewByOrderID(ctx context.Context, orderID int64) ([]*model.ReviewInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*model.ReviewInfo
+	for _, review := range r.reviews {
+		if review.OrderID == orderID && review.DeleteAt == nil {
+			result = append(result, review)
+		}
+	}
+	return result, nil
+}
+
+// ListReviewedSkuIDs 返回订单下已有评论的商品，包括已删除的评论
+func (r *memoryReviewRepo) ListReviewedSkuIDs(ctx context.Context, orderID int64) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var skuIDs []int64
+	for _, review := range r.reviews {
+		if review.OrderID == orderID {
+			skuIDs = append(skuIDs, review.SkuID)
+		}
+	}
+	return skuIDs, nil
+}
+
+// GetReviewByReviewID 返回未删除的评论，不存在时返回gorm.ErrRecordNotFound
+func (r *memoryReviewRepo) GetReviewByReviewID(ctx context.Context, reviewID int64) (*model.ReviewInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, review := range r.reviews {
+		if review.ReviewID == reviewID && review.DeleteAt == nil {
+			return review, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryReviewRepo) SaveReview(ctx context.Context, review *model.ReviewInfo) (int64, error) {
+	reviewIDs, err := r.BatchSaveReviews(ctx, []*model.ReviewInfo{review})
+	if err != nil {
+		return 0, err
+	}
+	return reviewIDs[0], nil
+}
+
+// BatchSaveReviews 全部成功或全部失败，商品行已存在评论时返回ErrReviewRepeated
+func (r *memoryReviewRepo) BatchSaveReviews(ctx context.Context, reviews []*model.ReviewInfo) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	type line struct{ orderID, skuID int64 }
+	taken := make(map[line]struct{}, len(r.reviews)+len(reviews))
+	for _, review := range r.reviews {
+		taken[line{review.OrderID, review.SkuID}] = struct{}{}
+	}
+	for _, review := range reviews {
+		key := line{review.OrderID, review.SkuID}
+		if _, ok := taken[key]; ok {
+			return nil, ErrReviewRepeated
+		}
+		taken[key] = struct{}{}
+	}
+	reviewIDs := make([]int64, len(reviews))
+	for i, review := range reviews {
+		r.reviews = append(r.reviews, review)
+		reviewIDs[i] = review.ReviewID
+	}
+	return reviewIDs, nil
+}
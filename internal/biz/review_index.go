@@ -0,0 +1,97 @@
+package biz
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// 评论ES同步事件状态
+const (
+	IndexEventStatusPending int32 = 10 // 待同步
+	IndexEventStatusDone    int32 = 20 // 已同步
+	IndexEventStatusDead    int32 = 30 // 超过重试次数，进入死信
+)
+
+// 重试退避时间上限
+const maxIndexRetryBackoff = 5 * time.Minute
+
+// ReviewIndexEvent 评论ES同步事件，与评论变更在同一事务中写入
+type ReviewIndexEvent struct {
+	ID         int64
+	ReviewID   int64
+	RetryCount int32
+}
+
+// ReviewIndexRepo 评论ES索引同步repo
+type ReviewIndexRepo interface {
+	ListPendingIndexEvents(context.Context, int) ([]*ReviewIndexEvent, error)
+	// SyncReviewIndex 以MySQL中的评论为准写入或删除ES文档，version为触发同步的最大事件ID，
+	// 作为ES外部版本号，多副本并发同步时旧快照不会覆盖新快照
+	SyncReviewIndex(ctx context.Context, reviewID int64, version int64) error
+	MarkIndexEventsDone(context.Context, ...int64) error
+	MarkIndexEventFailed(context.Context, *ReviewIndexEvent, time.Time, bool, string) error
+}
+
+// ReviewIndexUsecase 评论ES索引同步
+type ReviewIndexUsecase struct {
+	repo ReviewIndexRepo
+	log  *log.Helper
+}
+
+func NewReviewIndexUsecase(repo ReviewIndexRepo, logger log.Logger) *ReviewIndexUsecase {
+	return &ReviewIndexUsecase{repo: repo, log: log.NewHelper(logger)}
+}
+
+// SyncPending 处理一批待同步事件，返回处理的事件数
+func (uc *ReviewIndexUsecase) SyncPending(ctx context.Context, batchSize int, maxRetries int32) (int, error) {
+	events, err := uc.repo.ListPendingIndexEvents(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// 同一条评论的多个事件只需同步一次
+	byReview := make(map[int64][]*ReviewIndexEvent, len(events))
+	for _, event := range events {
+		byReview[event.ReviewID] = append(byReview[event.ReviewID], event)
+	}
+	for reviewID, reviewEvents := range byReview {
+		var version int64
+		for _, event := range reviewEvents {
+			version = max(version, event.ID)
+		}
+		if err := uc.repo.SyncReviewIndex(ctx, reviewID, version); err != nil {
+			uc.markFailed(ctx, reviewEvents, maxRetries, err)
+			continue
+		}
+		ids := make([]int64, len(reviewEvents))
+		for i, event := range reviewEvents {
+			ids[i] = event.ID
+		}
+		if err := uc.repo.MarkIndexEventsDone(ctx, ids...); err != nil {
+			uc.log.WithContext(ctx).Errorf("更新同步事件状态失败[review_id:%d]，%v", reviewID, err)
+		}
+	}
+	return len(events), nil
+}
+
+// markFailed 同步失败按指数退避重试，超过最大重试次数进入死信
+func (uc *ReviewIndexUsecase) markFailed(ctx context.Context, events []*ReviewIndexEvent, maxRetries int32, syncErr error) {
+	for _, event := range events {
+		event.RetryCount++
+		dead := event.RetryCount >= maxRetries
+		backoff := time.Duration(1<<min(event.RetryCount, 16)) * time.Second
+		if backoff > maxIndexRetryBackoff {
+			backoff = maxIndexRetryBackoff
+		}
+		if dead {
+			uc.log.WithContext(ctx).Errorf("评论同步ES失败，进入死信[review_id:%d]，%v", event.ReviewID, syncErr)
+		} else {
+			uc.log.WithContext(ctx).Warnf("评论同步ES失败，%s后重试[review_id:%d]，%v", backoff, event.ReviewID, syncErr)
+		}
+		if err := uc.repo.MarkIndexEventFailed(ctx, event, time.Now().Add(backoff), dead, syncErr.Error()); err != nil {
+			uc.log.WithContext(ctx).Errorf("更新同步事件状态失败[review_id:%d]，%v", event.ReviewID, err)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package biz
+
+// reviewStatusTransitions 评论状态机，key为当前状态，value为允许流转到的状态
+var reviewStatusTransitions = map[int32][]int32{
+	ReviewStatusPending:  {ReviewStatusApproved, ReviewStatusRejected}, // 待审核 -> 审核通过/审核不通过
+	ReviewStatusApproved: {ReviewStatusHidden},                         // 审核通过 -> 隐藏
+	ReviewStatusRejected: {ReviewStatusApproved},                       // 审核不通过 -> 复审通过
+	ReviewStatusHidden:   {ReviewStatusApproved},                       // 隐藏 -> 恢复展示
+}
+
+// CanTransitReviewStatus 判断评论状态能否从from流转到to
+func CanTransitReviewStatus(from, to int32) bool {
+	for _, s := range reviewStatusTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "review-service/api/review/v1"
+	"review-service/internal/data/model"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+func TestSaveReviewHasMedia(t *testing.T) {
+	orders := NewMemoryOrderClient(&Order{OrderID: 1, UserID: 10, StoreID: 100, Status: OrderStatusCompleted,
+		CompleteAt: time.Now().Add(-time.Hour),
+		Items:      []*OrderItem{{SkuID: 1000, SpuID: 2000}, {SkuID: 1001, SpuID: 2000}, {SkuID: 1002, SpuID: 2000}}})
+	products := NewMemoryProductClient(
+		&Sku{SkuID: 1000, SpuID: 2000},
+		&Sku{SkuID: 1001, SpuID: 2000},
+		&Sku{SkuID: 1002, SpuID: 2000},
+	)
+	repo := &memoryReviewRepo{}
+	uc := &ReviewUsecase{repo: repo, orderClient: orders, productClient: products, reviewWindow: defaultReviewWindow,
+		log: log.NewHelper(log.DefaultLogger)}
+
+	tests := []struct {
+		name   string
+		review *model.ReviewInfo
+		want   int32
+	}{
+		{"picture", &model.ReviewInfo{SkuID: 1000, PicInfo: `["a.jpg"]`}, 1},
+		{"video", &model.ReviewInfo{SkuID: 1001, VideoInfo: `["a.mp4"]`}, 1},
+		{"text only", &model.ReviewInfo{SkuID: 1002}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.review
+			r.OrderID, r.UserID, r.StoreID, r.SpuID, r.Content, r.Score = 1, 10, 100, 2000, "好", 5
+			if _, err := uc.SaveReview(context.Background(), r); err != nil {
+				t.Fatalf("save review: %v", err)
+			}
+			if r.HasMedia != tt.want {
+				t.Fatalf("want has_media %d, got %d", tt.want, r.HasMedia)
+			}
+		})
+	}
+}
+
+func TestUpdateHiddenReview(t *testing.T) {
+	repo := &memoryReviewRepo{reviews: []*model.ReviewInfo{
+		{ReviewID: 1, UserID: 10, Status: ReviewStatusHidden, Version: 3, CreateAt: time.Now()},
+	}}
+	uc := &ReviewUsecase{repo: repo, editWindow: defaultEditWindow, log: log.NewHelper(log.DefaultLogger)}
+
+	_, err := uc.UpdateReview(context.Background(), &UpdateReview{ReviewID: 1, UserID: 10, Version: 3, Content: "改", Score: 5})
+	if got, want := errors.Reason(err), v1.ErrorReviewStatusTransitionErr("").Reason; got != want {
+		t.Fatalf("want reason %s, got %v", want, err)
+	}
+	if repo.reviews[0].Status != ReviewStatusHidden {
+		t.Fatalf("want review still hidden, got status %d", repo.reviews[0].Status)
+	}
+}
@@ -0,0 +1,17 @@
+package biz
+
+import (
+	"context"
+)
+
+// 用户资料
+type UserProfile struct {
+	UserID   int64
+	Nickname string
+	Avatar   string
+}
+
+// UserClient 用户服务客户端，不存在的用户不出现在返回结果中
+type UserClient interface {
+	BatchGetUsers(ctx context.Context, userIDs []int64) (map[int64]*UserProfile, error)
+}
@@ -0,0 +1,42 @@
+package biz
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryUserClient 内存版用户服务客户端，用于测试
+type MemoryUserClient struct {
+	mu    sync.RWMutex
+	users map[int64]*UserProfile
+}
+
+// NewMemoryUserClient .
+func NewMemoryUserClient(users ...*UserProfile) *MemoryUserClient {
+	c := &MemoryUserClient{users: make(map[int64]*UserProfile, len(users))}
+	for _, user := range users {
+		c.users[user.UserID] = user
+	}
+	return c
+}
+
+// Put 添加或覆盖用户资料
+func (c *MemoryUserClient) Put(user *UserProfile) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.users[user.UserID] = user
+}
+
+// BatchGetUsers 返回用户资料的副本
+func (c *MemoryUserClient) BatchGetUsers(ctx context.Context, userIDs []int64) (map[int64]*UserProfile, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	users := make(map[int64]*UserProfile, len(userIDs))
+	for _, id := range userIDs {
+		if user, ok := c.users[id]; ok {
+			u := *user
+			users[id] = &u
+		}
+	}
+	return users, nil
+}
@@ -30,6 +30,7 @@ type Bootstrap struct {
 	Registry      *Registry              `protobuf:"bytes,4,opt,name=registry,proto3" json:"registry,omitempty"`
 	Node          *Node                  `protobuf:"bytes,5,opt,name=node,proto3" json:"node,omitempty"`
 	Elasticsearch *Elasticsearch         `protobuf:"bytes,6,opt,name=elasticsearch,proto3" json:"elasticsearch,omitempty"`
+	Review        *Review                `protobuf:"bytes,7,opt,name=review,proto3" json:"review,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -106,12 +107,22 @@ func (x *Bootstrap) GetElasticsearch() *Elasticsearch {
 	return nil
 }
 
+func (x *Bootstrap) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
 type Server struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Http          *Server_HTTP           `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
-	Grpc          *Server_GRPC           `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState  `protogen:"open.v1"`
+	Http            *Server_HTTP            `protobuf:"bytes,1,opt,name=http,proto3" json:"http,omitempty"`
+	Grpc            *Server_GRPC            `protobuf:"bytes,2,opt,name=grpc,proto3" json:"grpc,omitempty"`
+	Indexer         *Server_Indexer         `protobuf:"bytes,3,opt,name=indexer,proto3" json:"indexer,omitempty"`
+	DefaultReviewer *Server_DefaultReviewer `protobuf:"bytes,4,opt,name=default_reviewer,json=defaultReviewer,proto3" json:"default_reviewer,omitempty"`
+	Auth            *Server_Auth            `protobuf:"bytes,5,opt,name=auth,proto3" json:"auth,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Server) Reset() {
@@ -158,12 +169,38 @@ func (x *Server) GetGrpc() *Server_GRPC {
 	return nil
 }
 
+func (x *Server) GetIndexer() *Server_Indexer {
+	if x != nil {
+		return x.Indexer
+	}
+	return nil
+}
+
+func (x *Server) GetDefaultReviewer() *Server_DefaultReviewer {
+	if x != nil {
+		return x.DefaultReviewer
+	}
+	return nil
+}
+
+func (x *Server) GetAuth() *Server_Auth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
 type Data struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Database      *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
-	Redis         *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Database       *Data_Database         `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	Redis          *Data_Redis            `protobuf:"bytes,2,opt,name=redis,proto3" json:"redis,omitempty"`
+	LocalCache     *Data_LocalCache       `protobuf:"bytes,3,opt,name=local_cache,json=localCache,proto3" json:"local_cache,omitempty"`
+	Cache          *Data_Cache            `protobuf:"bytes,4,opt,name=cache,proto3" json:"cache,omitempty"`
+	OrderService   *Data_OrderService     `protobuf:"bytes,5,opt,name=order_service,json=orderService,proto3" json:"order_service,omitempty"`
+	ProductService *Data_ProductService   `protobuf:"bytes,6,opt,name=product_service,json=productService,proto3" json:"product_service,omitempty"`
+	UserService    *Data_UserService      `protobuf:"bytes,7,opt,name=user_service,json=userService,proto3" json:"user_service,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Data) Reset() {
@@ -210,6 +247,41 @@ func (x *Data) GetRedis() *Data_Redis {
 	return nil
 }
 
+func (x *Data) GetLocalCache() *Data_LocalCache {
+	if x != nil {
+		return x.LocalCache
+	}
+	return nil
+}
+
+func (x *Data) GetCache() *Data_Cache {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+func (x *Data) GetOrderService() *Data_OrderService {
+	if x != nil {
+		return x.OrderService
+	}
+	return nil
+}
+
+func (x *Data) GetProductService() *Data_ProductService {
+	if x != nil {
+		return x.ProductService
+	}
+	return nil
+}
+
+func (x *Data) GetUserService() *Data_UserService {
+	if x != nil {
+		return x.UserService
+	}
+	return nil
+}
+
 type SnowFlake struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StartTime     string                 `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
@@ -418,6 +490,83 @@ func (x *Elasticsearch) GetAddresses() []string {
 	return nil
 }
 
+// 评论业务规则
+type Review struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	AppendWindow       *durationpb.Duration   `protobuf:"bytes,1,opt,name=append_window,json=appendWindow,proto3" json:"append_window,omitempty"`                     // 原评论创建后允许追评的时长
+	EditWindow         *durationpb.Duration   `protobuf:"bytes,2,opt,name=edit_window,json=editWindow,proto3" json:"edit_window,omitempty"`                           // 原评论创建后允许修改、删除的时长
+	ReviewWindow       *durationpb.Duration   `protobuf:"bytes,3,opt,name=review_window,json=reviewWindow,proto3" json:"review_window,omitempty"`                     // 订单完成后允许评论的时长
+	DefaultReviewAfter *durationpb.Duration   `protobuf:"bytes,4,opt,name=default_review_after,json=defaultReviewAfter,proto3" json:"default_review_after,omitempty"` // 订单完成后超过该时长未评论的商品自动默认好评，不小于review_window
+	AnonymousSecret    string                 `protobuf:"bytes,5,opt,name=anonymous_secret,json=anonymousSecret,proto3" json:"anonymous_secret,omitempty"`            // 匿名评论用户标识的签名密钥，多副本需配置相同的值
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_conf_conf_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Review) GetAppendWindow() *durationpb.Duration {
+	if x != nil {
+		return x.AppendWindow
+	}
+	return nil
+}
+
+func (x *Review) GetEditWindow() *durationpb.Duration {
+	if x != nil {
+		return x.EditWindow
+	}
+	return nil
+}
+
+func (x *Review) GetReviewWindow() *durationpb.Duration {
+	if x != nil {
+		return x.ReviewWindow
+	}
+	return nil
+}
+
+func (x *Review) GetDefaultReviewAfter() *durationpb.Duration {
+	if x != nil {
+		return x.DefaultReviewAfter
+	}
+	return nil
+}
+
+func (x *Review) GetAnonymousSecret() string {
+	if x != nil {
+		return x.AnonymousSecret
+	}
+	return ""
+}
+
 type Server_HTTP struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Network       string                 `protobuf:"bytes,1,opt,name=network,proto3" json:"network,omitempty"`
@@ -429,7 +578,7 @@ type Server_HTTP struct {
 
 func (x *Server_HTTP) Reset() {
 	*x = Server_HTTP{}
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -441,7 +590,7 @@ func (x *Server_HTTP) String() string {
 func (*Server_HTTP) ProtoMessage() {}
 
 func (x *Server_HTTP) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[7]
+	mi := &file_conf_conf_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +638,7 @@ type Server_GRPC struct {
 
 func (x *Server_GRPC) Reset() {
 	*x = Server_GRPC{}
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +650,7 @@ func (x *Server_GRPC) String() string {
 func (*Server_GRPC) ProtoMessage() {}
 
 func (x *Server_GRPC) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[8]
+	mi := &file_conf_conf_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -538,6 +687,173 @@ func (x *Server_GRPC) GetTimeout() *durationpb.Duration {
 	return nil
 }
 
+// 评论ES索引同步任务
+type Server_Indexer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	MaxRetries    int32                  `protobuf:"varint,3,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Indexer) Reset() {
+	*x = Server_Indexer{}
+	mi := &file_conf_conf_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Indexer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Indexer) ProtoMessage() {}
+
+func (x *Server_Indexer) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Indexer.ProtoReflect.Descriptor instead.
+func (*Server_Indexer) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *Server_Indexer) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Server_Indexer) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Server_Indexer) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+// 默认评价后台任务
+type Server_DefaultReviewer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Interval      *durationpb.Duration   `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	BatchSize     int32                  `protobuf:"varint,2,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	LockTtl       *durationpb.Duration   `protobuf:"bytes,3,opt,name=lock_ttl,json=lockTtl,proto3" json:"lock_ttl,omitempty"` // 分布式锁过期时间，需大于单次执行耗时
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_DefaultReviewer) Reset() {
+	*x = Server_DefaultReviewer{}
+	mi := &file_conf_conf_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_DefaultReviewer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_DefaultReviewer) ProtoMessage() {}
+
+func (x *Server_DefaultReviewer) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_DefaultReviewer.ProtoReflect.Descriptor instead.
+func (*Server_DefaultReviewer) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 3}
+}
+
+func (x *Server_DefaultReviewer) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Server_DefaultReviewer) GetBatchSize() int32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *Server_DefaultReviewer) GetLockTtl() *durationpb.Duration {
+	if x != nil {
+		return x.LockTtl
+	}
+	return nil
+}
+
+// 调用方身份认证
+type Server_Auth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JwtSecret     string                 `protobuf:"bytes,1,opt,name=jwt_secret,json=jwtSecret,proto3" json:"jwt_secret,omitempty"` // HS256签名密钥，未配置时拒绝所有携带token的请求
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Server_Auth) Reset() {
+	*x = Server_Auth{}
+	mi := &file_conf_conf_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Server_Auth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Server_Auth) ProtoMessage() {}
+
+func (x *Server_Auth) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Server_Auth.ProtoReflect.Descriptor instead.
+func (*Server_Auth) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *Server_Auth) GetJwtSecret() string {
+	if x != nil {
+		return x.JwtSecret
+	}
+	return ""
+}
+
 type Data_Database struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Driver        string                 `protobuf:"bytes,1,opt,name=driver,proto3" json:"driver,omitempty"`
@@ -548,7 +864,7 @@ type Data_Database struct {
 
 func (x *Data_Database) Reset() {
 	*x = Data_Database{}
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -560,7 +876,7 @@ func (x *Data_Database) String() string {
 func (*Data_Database) ProtoMessage() {}
 
 func (x *Data_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[9]
+	mi := &file_conf_conf_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,7 +917,7 @@ type Data_Redis struct {
 
 func (x *Data_Redis) Reset() {
 	*x = Data_Redis{}
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -613,7 +929,7 @@ func (x *Data_Redis) String() string {
 func (*Data_Redis) ProtoMessage() {}
 
 func (x *Data_Redis) ProtoReflect() protoreflect.Message {
-	mi := &file_conf_conf_proto_msgTypes[10]
+	mi := &file_conf_conf_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -650,22 +966,321 @@ func (x *Data_Redis) GetPassword() string {
 	return ""
 }
 
+type Data_LocalCache struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int32                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	ListTtl       *durationpb.Duration   `protobuf:"bytes,2,opt,name=list_ttl,json=listTtl,proto3" json:"list_ttl,omitempty"`
+	DetailTtl     *durationpb.Duration   `protobuf:"bytes,3,opt,name=detail_ttl,json=detailTtl,proto3" json:"detail_ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_LocalCache) Reset() {
+	*x = Data_LocalCache{}
+	mi := &file_conf_conf_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_LocalCache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_LocalCache) ProtoMessage() {}
+
+func (x *Data_LocalCache) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_LocalCache.ProtoReflect.Descriptor instead.
+func (*Data_LocalCache) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 2}
+}
+
+func (x *Data_LocalCache) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Data_LocalCache) GetListTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ListTtl
+	}
+	return nil
+}
+
+func (x *Data_LocalCache) GetDetailTtl() *durationpb.Duration {
+	if x != nil {
+		return x.DetailTtl
+	}
+	return nil
+}
+
+type Data_Cache struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListTtl       *durationpb.Duration   `protobuf:"bytes,1,opt,name=list_ttl,json=listTtl,proto3" json:"list_ttl,omitempty"`
+	DetailTtl     *durationpb.Duration   `protobuf:"bytes,2,opt,name=detail_ttl,json=detailTtl,proto3" json:"detail_ttl,omitempty"`
+	EmptyTtl      *durationpb.Duration   `protobuf:"bytes,3,opt,name=empty_ttl,json=emptyTtl,proto3" json:"empty_ttl,omitempty"`
+	JitterPercent int32                  `protobuf:"varint,4,opt,name=jitter_percent,json=jitterPercent,proto3" json:"jitter_percent,omitempty"`
+	MaxValueSize  int32                  `protobuf:"varint,5,opt,name=max_value_size,json=maxValueSize,proto3" json:"max_value_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_Cache) Reset() {
+	*x = Data_Cache{}
+	mi := &file_conf_conf_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_Cache) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_Cache) ProtoMessage() {}
+
+func (x *Data_Cache) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_Cache.ProtoReflect.Descriptor instead.
+func (*Data_Cache) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Data_Cache) GetListTtl() *durationpb.Duration {
+	if x != nil {
+		return x.ListTtl
+	}
+	return nil
+}
+
+func (x *Data_Cache) GetDetailTtl() *durationpb.Duration {
+	if x != nil {
+		return x.DetailTtl
+	}
+	return nil
+}
+
+func (x *Data_Cache) GetEmptyTtl() *durationpb.Duration {
+	if x != nil {
+		return x.EmptyTtl
+	}
+	return nil
+}
+
+func (x *Data_Cache) GetJitterPercent() int32 {
+	if x != nil {
+		return x.JitterPercent
+	}
+	return 0
+}
+
+func (x *Data_Cache) GetMaxValueSize() int32 {
+	if x != nil {
+		return x.MaxValueSize
+	}
+	return 0
+}
+
+// 订单服务，endpoint通过注册中心发现
+type Data_OrderService struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_OrderService) Reset() {
+	*x = Data_OrderService{}
+	mi := &file_conf_conf_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_OrderService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_OrderService) ProtoMessage() {}
+
+func (x *Data_OrderService) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_OrderService.ProtoReflect.Descriptor instead.
+func (*Data_OrderService) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 4}
+}
+
+func (x *Data_OrderService) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Data_OrderService) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// 商品服务，endpoint通过注册中心发现
+type Data_ProductService struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_ProductService) Reset() {
+	*x = Data_ProductService{}
+	mi := &file_conf_conf_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_ProductService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_ProductService) ProtoMessage() {}
+
+func (x *Data_ProductService) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_ProductService.ProtoReflect.Descriptor instead.
+func (*Data_ProductService) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 5}
+}
+
+func (x *Data_ProductService) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Data_ProductService) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// 用户服务，endpoint通过注册中心发现
+type Data_UserService struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Endpoint      string                 `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,2,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Data_UserService) Reset() {
+	*x = Data_UserService{}
+	mi := &file_conf_conf_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Data_UserService) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Data_UserService) ProtoMessage() {}
+
+func (x *Data_UserService) ProtoReflect() protoreflect.Message {
+	mi := &file_conf_conf_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Data_UserService.ProtoReflect.Descriptor instead.
+func (*Data_UserService) Descriptor() ([]byte, []int) {
+	return file_conf_conf_proto_rawDescGZIP(), []int{2, 6}
+}
+
+func (x *Data_UserService) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+func (x *Data_UserService) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 var File_conf_conf_proto protoreflect.FileDescriptor
 
 const file_conf_conf_proto_rawDesc = "" +
 	"\n" +
 	"\x0fconf/conf.proto\x12\n" +
-	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xab\x02\n" +
+	"kratos.api\x1a\x1egoogle/protobuf/duration.proto\"\xd7\x02\n" +
 	"\tBootstrap\x12*\n" +
 	"\x06server\x18\x01 \x01(\v2\x12.kratos.api.ServerR\x06server\x12$\n" +
 	"\x04data\x18\x02 \x01(\v2\x10.kratos.api.DataR\x04data\x123\n" +
 	"\tsnowflake\x18\x03 \x01(\v2\x15.kratos.api.SnowFlakeR\tsnowflake\x120\n" +
 	"\bregistry\x18\x04 \x01(\v2\x14.kratos.api.RegistryR\bregistry\x12$\n" +
 	"\x04node\x18\x05 \x01(\v2\x10.kratos.api.NodeR\x04node\x12?\n" +
-	"\relasticsearch\x18\x06 \x01(\v2\x19.kratos.api.ElasticsearchR\relasticsearch\"\xb8\x02\n" +
+	"\relasticsearch\x18\x06 \x01(\v2\x19.kratos.api.ElasticsearchR\relasticsearch\x12*\n" +
+	"\x06review\x18\a \x01(\v2\x12.kratos.api.ReviewR\x06review\"\xb4\x06\n" +
 	"\x06Server\x12+\n" +
 	"\x04http\x18\x01 \x01(\v2\x17.kratos.api.Server.HTTPR\x04http\x12+\n" +
-	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x1ai\n" +
+	"\x04grpc\x18\x02 \x01(\v2\x17.kratos.api.Server.GRPCR\x04grpc\x124\n" +
+	"\aindexer\x18\x03 \x01(\v2\x1a.kratos.api.Server.IndexerR\aindexer\x12M\n" +
+	"\x10default_reviewer\x18\x04 \x01(\v2\".kratos.api.Server.DefaultReviewerR\x0fdefaultReviewer\x12+\n" +
+	"\x04auth\x18\x05 \x01(\v2\x17.kratos.api.Server.AuthR\x04auth\x1ai\n" +
 	"\x04HTTP\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
@@ -673,17 +1288,59 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04GRPC\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x123\n" +
-	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\xfa\x01\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a\x80\x01\n" +
+	"\aIndexer\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x12\x1f\n" +
+	"\vmax_retries\x18\x03 \x01(\x05R\n" +
+	"maxRetries\x1a\x9d\x01\n" +
+	"\x0fDefaultReviewer\x125\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\binterval\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\x02 \x01(\x05R\tbatchSize\x124\n" +
+	"\block_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\alockTtl\x1a%\n" +
+	"\x04Auth\x12\x1d\n" +
+	"\n" +
+	"jwt_secret\x18\x01 \x01(\tR\tjwtSecret\"\xeb\t\n" +
 	"\x04Data\x125\n" +
 	"\bdatabase\x18\x01 \x01(\v2\x19.kratos.api.Data.DatabaseR\bdatabase\x12,\n" +
-	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x1a:\n" +
+	"\x05redis\x18\x02 \x01(\v2\x16.kratos.api.Data.RedisR\x05redis\x12<\n" +
+	"\vlocal_cache\x18\x03 \x01(\v2\x1b.kratos.api.Data.LocalCacheR\n" +
+	"localCache\x12,\n" +
+	"\x05cache\x18\x04 \x01(\v2\x16.kratos.api.Data.CacheR\x05cache\x12B\n" +
+	"\rorder_service\x18\x05 \x01(\v2\x1d.kratos.api.Data.OrderServiceR\forderService\x12H\n" +
+	"\x0fproduct_service\x18\x06 \x01(\v2\x1f.kratos.api.Data.ProductServiceR\x0eproductService\x12?\n" +
+	"\fuser_service\x18\a \x01(\v2\x1c.kratos.api.Data.UserServiceR\vuserService\x1a:\n" +
 	"\bDatabase\x12\x16\n" +
 	"\x06driver\x18\x01 \x01(\tR\x06driver\x12\x16\n" +
 	"\x06source\x18\x02 \x01(\tR\x06source\x1aQ\n" +
 	"\x05Redis\x12\x18\n" +
 	"\anetwork\x18\x01 \x01(\tR\anetwork\x12\x12\n" +
 	"\x04addr\x18\x02 \x01(\tR\x04addr\x12\x1a\n" +
-	"\bpassword\x18\x03 \x01(\tR\bpassword\"I\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x1a\x90\x01\n" +
+	"\n" +
+	"LocalCache\x12\x12\n" +
+	"\x04size\x18\x01 \x01(\x05R\x04size\x124\n" +
+	"\blist_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\alistTtl\x128\n" +
+	"\n" +
+	"detail_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\tdetailTtl\x1a\xfc\x01\n" +
+	"\x05Cache\x124\n" +
+	"\blist_ttl\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\alistTtl\x128\n" +
+	"\n" +
+	"detail_ttl\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\tdetailTtl\x126\n" +
+	"\tempty_ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bemptyTtl\x12%\n" +
+	"\x0ejitter_percent\x18\x04 \x01(\x05R\rjitterPercent\x12$\n" +
+	"\x0emax_value_size\x18\x05 \x01(\x05R\fmaxValueSize\x1a_\n" +
+	"\fOrderService\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1aa\n" +
+	"\x0eProductService\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\x1a^\n" +
+	"\vUserService\x12\x1a\n" +
+	"\bendpoint\x18\x01 \x01(\tR\bendpoint\x123\n" +
+	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"I\n" +
 	"\tSnowFlake\x12\x1d\n" +
 	"\n" +
 	"start_time\x18\x01 \x01(\tR\tstartTime\x12\x1d\n" +
@@ -697,7 +1354,14 @@ const file_conf_conf_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x03 \x01(\tR\aversion\"-\n" +
 	"\rElasticsearch\x12\x1c\n" +
-	"\taddresses\x18\x01 \x03(\tR\taddressesB#Z!review-service/internal/conf;confb\x06proto3"
+	"\taddresses\x18\x01 \x03(\tR\taddresses\"\xbc\x02\n" +
+	"\x06Review\x12>\n" +
+	"\rappend_window\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\fappendWindow\x12:\n" +
+	"\vedit_window\x18\x02 \x01(\v2\x19.google.protobuf.DurationR\n" +
+	"editWindow\x12>\n" +
+	"\rreview_window\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\freviewWindow\x12K\n" +
+	"\x14default_review_after\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x12defaultReviewAfter\x12)\n" +
+	"\x10anonymous_secret\x18\x05 \x01(\tR\x0fanonymousSecretB#Z!review-service/internal/conf;confb\x06proto3"
 
 var (
 	file_conf_conf_proto_rawDescOnce sync.Once
@@ -711,20 +1375,29 @@ func file_conf_conf_proto_rawDescGZIP() []byte {
 	return file_conf_conf_proto_rawDescData
 }
 
-var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_conf_conf_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_conf_conf_proto_goTypes = []any{
-	(*Bootstrap)(nil),           // 0: kratos.api.Bootstrap
-	(*Server)(nil),              // 1: kratos.api.Server
-	(*Data)(nil),                // 2: kratos.api.Data
-	(*SnowFlake)(nil),           // 3: kratos.api.SnowFlake
-	(*Registry)(nil),            // 4: kratos.api.Registry
-	(*Node)(nil),                // 5: kratos.api.Node
-	(*Elasticsearch)(nil),       // 6: kratos.api.Elasticsearch
-	(*Server_HTTP)(nil),         // 7: kratos.api.Server.HTTP
-	(*Server_GRPC)(nil),         // 8: kratos.api.Server.GRPC
-	(*Data_Database)(nil),       // 9: kratos.api.Data.Database
-	(*Data_Redis)(nil),          // 10: kratos.api.Data.Redis
-	(*durationpb.Duration)(nil), // 11: google.protobuf.Duration
+	(*Bootstrap)(nil),              // 0: kratos.api.Bootstrap
+	(*Server)(nil),                 // 1: kratos.api.Server
+	(*Data)(nil),                   // 2: kratos.api.Data
+	(*SnowFlake)(nil),              // 3: kratos.api.SnowFlake
+	(*Registry)(nil),               // 4: kratos.api.Registry
+	(*Node)(nil),                   // 5: kratos.api.Node
+	(*Elasticsearch)(nil),          // 6: kratos.api.Elasticsearch
+	(*Review)(nil),                 // 7: kratos.api.Review
+	(*Server_HTTP)(nil),            // 8: kratos.api.Server.HTTP
+	(*Server_GRPC)(nil),            // 9: kratos.api.Server.GRPC
+	(*Server_Indexer)(nil),         // 10: kratos.api.Server.Indexer
+	(*Server_DefaultReviewer)(nil), // 11: kratos.api.Server.DefaultReviewer
+	(*Server_Auth)(nil),            // 12: kratos.api.Server.Auth
+	(*Data_Database)(nil),          // 13: kratos.api.Data.Database
+	(*Data_Redis)(nil),             // 14: kratos.api.Data.Redis
+	(*Data_LocalCache)(nil),        // 15: kratos.api.Data.LocalCache
+	(*Data_Cache)(nil),             // 16: kratos.api.Data.Cache
+	(*Data_OrderService)(nil),      // 17: kratos.api.Data.OrderService
+	(*Data_ProductService)(nil),    // 18: kratos.api.Data.ProductService
+	(*Data_UserService)(nil),       // 19: kratos.api.Data.UserService
+	(*durationpb.Duration)(nil),    // 20: google.protobuf.Duration
 }
 var file_conf_conf_proto_depIdxs = []int32{
 	1,  // 0: kratos.api.Bootstrap.server:type_name -> kratos.api.Server
@@ -733,17 +1406,41 @@ var file_conf_conf_proto_depIdxs = []int32{
 	4,  // 3: kratos.api.Bootstrap.registry:type_name -> kratos.api.Registry
 	5,  // 4: kratos.api.Bootstrap.node:type_name -> kratos.api.Node
 	6,  // 5: kratos.api.Bootstrap.elasticsearch:type_name -> kratos.api.Elasticsearch
-	7,  // 6: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
-	8,  // 7: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
-	9,  // 8: kratos.api.Data.database:type_name -> kratos.api.Data.Database
-	10, // 9: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
-	11, // 10: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
-	11, // 11: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	7,  // 6: kratos.api.Bootstrap.review:type_name -> kratos.api.Review
+	8,  // 7: kratos.api.Server.http:type_name -> kratos.api.Server.HTTP
+	9,  // 8: kratos.api.Server.grpc:type_name -> kratos.api.Server.GRPC
+	10, // 9: kratos.api.Server.indexer:type_name -> kratos.api.Server.Indexer
+	11, // 10: kratos.api.Server.default_reviewer:type_name -> kratos.api.Server.DefaultReviewer
+	12, // 11: kratos.api.Server.auth:type_name -> kratos.api.Server.Auth
+	13, // 12: kratos.api.Data.database:type_name -> kratos.api.Data.Database
+	14, // 13: kratos.api.Data.redis:type_name -> kratos.api.Data.Redis
+	15, // 14: kratos.api.Data.local_cache:type_name -> kratos.api.Data.LocalCache
+	16, // 15: kratos.api.Data.cache:type_name -> kratos.api.Data.Cache
+	17, // 16: kratos.api.Data.order_service:type_name -> kratos.api.Data.OrderService
+	18, // 17: kratos.api.Data.product_service:type_name -> kratos.api.Data.ProductService
+	19, // 18: kratos.api.Data.user_service:type_name -> kratos.api.Data.UserService
+	20, // 19: kratos.api.Review.append_window:type_name -> google.protobuf.Duration
+	20, // 20: kratos.api.Review.edit_window:type_name -> google.protobuf.Duration
+	20, // 21: kratos.api.Review.review_window:type_name -> google.protobuf.Duration
+	20, // 22: kratos.api.Review.default_review_after:type_name -> google.protobuf.Duration
+	20, // 23: kratos.api.Server.HTTP.timeout:type_name -> google.protobuf.Duration
+	20, // 24: kratos.api.Server.GRPC.timeout:type_name -> google.protobuf.Duration
+	20, // 25: kratos.api.Server.Indexer.interval:type_name -> google.protobuf.Duration
+	20, // 26: kratos.api.Server.DefaultReviewer.interval:type_name -> google.protobuf.Duration
+	20, // 27: kratos.api.Server.DefaultReviewer.lock_ttl:type_name -> google.protobuf.Duration
+	20, // 28: kratos.api.Data.LocalCache.list_ttl:type_name -> google.protobuf.Duration
+	20, // 29: kratos.api.Data.LocalCache.detail_ttl:type_name -> google.protobuf.Duration
+	20, // 30: kratos.api.Data.Cache.list_ttl:type_name -> google.protobuf.Duration
+	20, // 31: kratos.api.Data.Cache.detail_ttl:type_name -> google.protobuf.Duration
+	20, // 32: kratos.api.Data.Cache.empty_ttl:type_name -> google.protobuf.Duration
+	20, // 33: kratos.api.Data.OrderService.timeout:type_name -> google.protobuf.Duration
+	20, // 34: kratos.api.Data.ProductService.timeout:type_name -> google.protobuf.Duration
+	20, // 35: kratos.api.Data.UserService.timeout:type_name -> google.protobuf.Duration
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_conf_conf_proto_init() }
@@ -757,7 +1454,7 @@ func file_conf_conf_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conf_conf_proto_rawDesc), len(file_conf_conf_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
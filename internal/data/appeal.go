@@ -2,7 +2,6 @@ package data
 
 import (
 	"context"
-	"errors"
 	"review-service/internal/biz"
 	"review-service/internal/data/model"
 	"review-service/internal/data/query"
@@ -24,50 +23,107 @@ func NewAppealRepo(data *Data, logger log.Logger) biz.AppealRepo {
 
 // SaveAppeal 创建申诉
 func (r *appealRepo) SaveAppeal(ctx context.Context, appeal *biz.Appeal) (int64, error) {
-	err := r.data.query.Transaction(func(tx *query.Query) error {
-		err := tx.ReviewAppealInfo.WithContext(ctx).Create(&model.ReviewAppealInfo{
-			AppealID:  appeal.AppealID,
-			ReviewID:  appeal.ReviewID,
-			StoreID:   appeal.StoreID,
-			Content:   appeal.Content,
-			PicInfo:   appeal.PicInfo,
-			VideoInfo: appeal.VideoInfo,
-		})
-		if err != nil {
-			return err
-		}
-		updateRes, err := tx.ReviewInfo.WithContext(ctx).Where(tx.ReviewInfo.ReviewID.Eq(appeal.ReviewID)).Update(tx.ReviewInfo.Status, appeal.Status)
-		if err != nil {
-			return err
-		}
-		if updateRes.RowsAffected == 0 {
-			return errors.New("更新评论状态失败")
-		}
-		return nil
+	err := r.data.query.ReviewAppealInfo.WithContext(ctx).Create(&model.ReviewAppealInfo{
+		AppealID:  appeal.AppealID,
+		ReviewID:  appeal.ReviewID,
+		StoreID:   appeal.StoreID,
+		Status:    appeal.Status,
+		Content:   appeal.Content,
+		PicInfo:   appeal.PicInfo,
+		VideoInfo: appeal.VideoInfo,
 	})
 	if err != nil {
 		return 0, err
 	}
+	if err := r.data.delReviewDetailCache(ctx, appeal.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
 	return appeal.AppealID, nil
 }
 
 // GetReviewByReviewID 根据reviewID获取评论
 func (r *appealRepo) GetReviewByReviewID(ctx context.Context, reviewID int64) (*model.ReviewInfo, error) {
-	review, err := r.data.query.ReviewInfo.WithContext(ctx).Where(r.data.query.ReviewInfo.ReviewID.Eq(reviewID)).First()
+	reviewInfo := r.data.query.ReviewInfo
+	review, err := reviewInfo.WithContext(ctx).Where(reviewInfo.ReviewID.Eq(reviewID), reviewInfo.DeleteAt.IsNull()).First()
 	if err != nil {
 		return nil, err
 	}
 	return review, nil
 }
 
-// UpdateReviewStatus 更新评论状态
-func (r *appealRepo) UpdateReviewStatus(ctx context.Context, reviewID int64, status int32) error {
-	updateRes, err := r.data.query.ReviewInfo.WithContext(ctx).Where(r.data.query.ReviewInfo.ReviewID.Eq(reviewID)).Update(r.data.query.ReviewInfo.Status, status)
+// GetAppealByAppealID 根据appealID获取申诉
+func (r *appealRepo) GetAppealByAppealID(ctx context.Context, appealID int64) (*model.ReviewAppealInfo, error) {
+	appeal, err := r.data.query.ReviewAppealInfo.WithContext(ctx).Where(r.data.query.ReviewAppealInfo.AppealID.Eq(appealID)).First()
+	if err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// GetPendingAppealByReviewID 获取评论待审核的申诉
+func (r *appealRepo) GetPendingAppealByReviewID(ctx context.Context, reviewID int64) (*model.ReviewAppealInfo, error) {
+	appealInfo := r.data.query.ReviewAppealInfo
+	appeal, err := appealInfo.WithContext(ctx).Where(appealInfo.ReviewID.Eq(reviewID), appealInfo.Status.Eq(biz.AppealStatusPending)).First()
+	if err != nil {
+		return nil, err
+	}
+	return appeal, nil
+}
+
+// AuditAppeal 审核申诉，申诉通过时隐藏评论
+func (r *appealRepo) AuditAppeal(ctx context.Context, audit *biz.AuditAppeal) error {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		// 1.更新申诉状态，只允许从待审核状态流转
+		updateRes, err := tx.ReviewAppealInfo.WithContext(ctx).
+			Where(tx.ReviewAppealInfo.AppealID.Eq(audit.AppealID), tx.ReviewAppealInfo.Status.Eq(biz.AppealStatusPending)).
+			UpdateSimple(
+				tx.ReviewAppealInfo.Status.Value(audit.Status),
+				tx.ReviewAppealInfo.OpUser.Value(audit.OpUser),
+				tx.ReviewAppealInfo.OpRemarks.Value(audit.OpRemarks),
+			)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return biz.ErrAppealAudited
+		}
+		if audit.Status != biz.AppealStatusApproved {
+			return nil
+		}
+
+		// 2.申诉通过，隐藏审核通过的评论
+		updateRes, err = tx.ReviewInfo.WithContext(ctx).
+			Where(tx.ReviewInfo.ReviewID.Eq(audit.ReviewID), tx.ReviewInfo.Status.Eq(biz.ReviewStatusApproved), tx.ReviewInfo.DeleteAt.IsNull()).
+			Update(tx.ReviewInfo.Status, biz.ReviewStatusHidden)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return biz.ErrReviewStatusChanged
+		}
+
+		// 3.评论移出评分统计
+		review, err := tx.ReviewInfo.WithContext(ctx).Where(tx.ReviewInfo.ReviewID.Eq(audit.ReviewID)).First()
+		if err != nil {
+			return err
+		}
+		if err := applyReviewStatusStat(ctx, tx, review, biz.ReviewStatusApproved, biz.ReviewStatusHidden); err != nil {
+			return err
+		}
+
+		// 4.写入ES同步事件
+		return addIndexEvent(ctx, tx, audit.ReviewID)
+	})
 	if err != nil {
 		return err
 	}
-	if updateRes.RowsAffected == 0 {
-		return errors.New("更新评论状态失败")
+	if err := r.data.delReviewDetailCache(ctx, audit.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	if audit.Status == biz.AppealStatusApproved {
+		if err := r.data.bumpStoreCacheGenByReviewID(ctx, audit.ReviewID); err != nil {
+			r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,92 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+
+	"gorm.io/gorm"
+)
+
+// SaveReviewAppend 创建追评，review_append_info上review_id的唯一索引兜底并发重复追评，冲突时返回biz.ErrReviewAppended
+func (r *reviewRepo) SaveReviewAppend(ctx context.Context, reviewAppend *model.ReviewAppendInfo) (int64, error) {
+	err := r.data.query.ReviewAppendInfo.WithContext(ctx).Create(reviewAppend)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return 0, biz.ErrReviewAppended
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := r.data.delReviewDetailCache(ctx, reviewAppend.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	return reviewAppend.AppendID, nil
+}
+
+// GetReviewAppendByReviewID 根据评论ID获取追评
+func (r *reviewRepo) GetReviewAppendByReviewID(ctx context.Context, reviewID int64) (*model.ReviewAppendInfo, error) {
+	reviewAppend := r.data.query.ReviewAppendInfo
+	return reviewAppend.WithContext(ctx).
+		Where(reviewAppend.ReviewID.Eq(reviewID), reviewAppend.DeleteAt.IsNull()).
+		First()
+}
+
+// GetReviewAppendByAppendID 根据追评ID获取追评
+func (r *reviewRepo) GetReviewAppendByAppendID(ctx context.Context, appendID int64) (*model.ReviewAppendInfo, error) {
+	reviewAppend := r.data.query.ReviewAppendInfo
+	return reviewAppend.WithContext(ctx).
+		Where(reviewAppend.AppendID.Eq(appendID), reviewAppend.DeleteAt.IsNull()).
+		First()
+}
+
+// AuditReviewAppend 运营审核追评，from为追评当前状态，防止并发审核覆盖
+func (r *reviewRepo) AuditReviewAppend(ctx context.Context, reviewID int64, audit *biz.AuditReviewAppend, from int32) error {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		updateRes, err := tx.ReviewAppendInfo.WithContext(ctx).
+			Where(tx.ReviewAppendInfo.AppendID.Eq(audit.AppendID), tx.ReviewAppendInfo.Status.Eq(from)).
+			UpdateSimple(
+				tx.ReviewAppendInfo.Status.Value(audit.Status),
+				tx.ReviewAppendInfo.OpReason.Value(audit.OpReason),
+				tx.ReviewAppendInfo.OpRemarks.Value(audit.OpRemarks),
+				tx.ReviewAppendInfo.OpUser.Value(audit.OpUser),
+			)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return errors.New("更新追评审核状态失败")
+		}
+		// 追评随评论文档写入ES
+		return addIndexEvent(ctx, tx, reviewID)
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.data.delReviewDetailCache(ctx, reviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	if err := r.data.bumpStoreCacheGenByReviewID(ctx, reviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
+	}
+	return nil
+}
+
+// getReviewAppends 批量查询评论的追评，按评论ID索引
+func (d *Data) getReviewAppends(ctx context.Context, reviewIDs []int64, approvedOnly bool) (map[int64]*model.ReviewAppendInfo, error) {
+	reviewAppend := d.query.ReviewAppendInfo
+	do := reviewAppend.WithContext(ctx).Where(reviewAppend.ReviewID.In(reviewIDs...), reviewAppend.DeleteAt.IsNull())
+	if approvedOnly {
+		do = do.Where(reviewAppend.Status.Eq(biz.ReviewStatusApproved))
+	}
+	appends, err := do.Find()
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]*model.ReviewAppendInfo, len(appends))
+	for _, a := range appends {
+		result[a.ReviewID] = a
+	}
+	return result, nil
+}
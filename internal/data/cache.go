@@ -0,0 +1,113 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"review-service/internal/conf"
+	"time"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/aegis/circuitbreaker/sre"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// cacheStats redis访问的熔断与失败指标
+type cacheStats struct {
+	breaker circuitbreaker.CircuitBreaker
+	errors  metric.Int64Counter
+}
+
+func newCacheStats() (*cacheStats, error) {
+	counter, err := otel.Meter("review-service/data").Int64Counter(
+		"review_cache_errors_total",
+		metric.WithDescription("redis访问失败或被熔断的次数"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &cacheStats{breaker: sre.NewBreaker(), errors: counter}, nil
+}
+
+// cacheCall 通过熔断器访问redis，熔断打开时直接返回错误，redis.Nil不计为失败
+func (d *Data) cacheCall(ctx context.Context, op string, fn func() error) error {
+	if err := d.cacheStats.breaker.Allow(); err != nil {
+		d.cacheStats.errors.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("op", op), attribute.String("reason", "breaker_open"),
+		))
+		return err
+	}
+	err := fn()
+	if err == nil || errors.Is(err, redis.Nil) {
+		d.cacheStats.breaker.MarkSuccess()
+		return err
+	}
+	d.cacheStats.breaker.MarkFailed()
+	d.cacheStats.errors.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("op", op), attribute.String("reason", "error"),
+	))
+	return err
+}
+
+// 缓存策略默认值
+const (
+	defaultCacheListTTL      = time.Minute
+	defaultCacheDetailTTL    = time.Minute
+	defaultCacheEmptyTTL     = 10 * time.Second
+	defaultCacheJitter       = 10
+	defaultCacheMaxValueSize = 512 << 10
+)
+
+// 空结果占位，用于缓存不存在的评论，防止缓存穿透
+var emptyCacheValue = []byte("null")
+
+// cachePolicy redis缓存策略
+type cachePolicy struct {
+	listTTL      time.Duration
+	detailTTL    time.Duration
+	emptyTTL     time.Duration
+	jitter       int32 // 过期时间随机增加的百分比，避免大量key同时过期
+	maxValueSize int   // 超过该大小的值不写缓存
+}
+
+func newCachePolicy(c *conf.Data_Cache) *cachePolicy {
+	p := &cachePolicy{
+		listTTL:      defaultCacheListTTL,
+		detailTTL:    defaultCacheDetailTTL,
+		emptyTTL:     defaultCacheEmptyTTL,
+		jitter:       defaultCacheJitter,
+		maxValueSize: defaultCacheMaxValueSize,
+	}
+	if c.GetListTtl() != nil {
+		p.listTTL = c.GetListTtl().AsDuration()
+	}
+	if c.GetDetailTtl() != nil {
+		p.detailTTL = c.GetDetailTtl().AsDuration()
+	}
+	if c.GetEmptyTtl() != nil {
+		p.emptyTTL = c.GetEmptyTtl().AsDuration()
+	}
+	if c.GetJitterPercent() > 0 {
+		p.jitter = c.GetJitterPercent()
+	}
+	if c.GetMaxValueSize() > 0 {
+		p.maxValueSize = int(c.GetMaxValueSize())
+	}
+	return p
+}
+
+// ttl 在基础过期时间上增加随机抖动
+func (p *cachePolicy) ttl(base time.Duration) time.Duration {
+	if base <= 0 || p.jitter <= 0 {
+		return base
+	}
+	return base + time.Duration(rand.Int63n(int64(base)*int64(p.jitter)/100+1))
+}
+
+// cacheable 值是否允许写入缓存
+func (p *cachePolicy) cacheable(data []byte) bool {
+	return len(data) <= p.maxValueSize
+}
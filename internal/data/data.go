@@ -14,13 +14,17 @@ import (
 )
 
 // ProviderSet is data providers.
-var ProviderSet = wire.NewSet(NewData, NewReviewRepo, NewDB, NewRedis, NewEsClient)
+var ProviderSet = wire.NewSet(NewData, NewReviewRepo, NewAppealRepo, NewReviewIndexRepo, NewDefaultReviewRepo, NewOrderServiceClient, NewOrderClient, NewProductServiceClient, NewProductClient, NewUserServiceClient, NewUserClient, NewDB, NewRedis, NewEsClient)
 
 // Data .
 type Data struct {
 	query    *query.Query
 	cache    *redis.Client
 	esClient *es.TypedClient
+
+	cacheStats  *cacheStats
+	cachePolicy *cachePolicy
+	localCache  *localCache
 }
 
 // NewData .
@@ -29,11 +33,26 @@ func NewData(c *conf.Data, logger log.Logger, db *gorm.DB, cache *redis.Client,
 		log.NewHelper(logger).Info("closing the data resources")
 	}
 	query.SetDefault(db) // 指定数据库
-	return &Data{query: query.Q, cache: cache, esClient: esClient}, cleanup, nil
+	stats, err := newCacheStats()
+	if err != nil {
+		return nil, nil, err
+	}
+	local, err := newLocalCache(c.LocalCache)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Data{
+		query:       query.Q,
+		cache:       cache,
+		esClient:    esClient,
+		cacheStats:  stats,
+		cachePolicy: newCachePolicy(c.Cache),
+		localCache:  local,
+	}, cleanup, nil
 }
 
 func NewDB(c *conf.Data) *gorm.DB {
-	db, err := gorm.Open(mysql.Open(c.Database.Source), &gorm.Config{})
+	db, err := gorm.Open(mysql.Open(c.Database.Source), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("database start failed: %#v", err)
 		panic(err)
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"review-service/internal/biz"
+	"strconv"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultReviewLockKey       = "review:default:lock"
+	defaultReviewCheckpointKey = "review:default:checkpoint"
+	defaultReviewParkedKey     = "review:default:parked" // zset，score为失败次数
+	defaultReviewDeadKey       = "review:default:dead"   // zset，score为转入死信的时间戳
+)
+
+// releaseLockScript 只释放自己持有的锁，避免锁过期后误删其他副本的锁
+var releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+type defaultReviewRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewDefaultReviewRepo .
+func NewDefaultReviewRepo(data *Data, logger log.Logger) biz.DefaultReviewRepo {
+	return &defaultReviewRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// TryLock 使用redis SET NX抢占分布式锁，ok为false表示锁被其他副本持有
+func (r *defaultReviewRepo) TryLock(ctx context.Context, ttl time.Duration) (func(), bool, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, false, err
+	}
+	token := hex.EncodeToString(buf)
+	ok, err := r.data.cache.SetNX(ctx, defaultReviewLockKey, token, ttl).Result()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	release := func() {
+		// 任务的ctx可能已取消，释放锁使用独立的ctx
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := releaseLockScript.Run(ctx, r.data.cache, []string{defaultReviewLockKey}, token).Err(); err != nil {
+			r.log.Warnf("释放默认评价任务锁失败: %v", err)
+		}
+	}
+	return release, true, nil
+}
+
+// GetCheckpoint 获取订单拉取进度，未记录时返回空字符串
+func (r *defaultReviewRepo) GetCheckpoint(ctx context.Context) (string, error) {
+	cursor, err := r.data.cache.Get(ctx, defaultReviewCheckpointKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return cursor, err
+}
+
+// SaveCheckpoint 保存订单拉取进度，丢失后从头拉取，已评价的商品会被跳过
+func (r *defaultReviewRepo) SaveCheckpoint(ctx context.Context, cursor string) error {
+	return r.data.cache.Set(ctx, defaultReviewCheckpointKey, cursor, 0).Err()
+}
+
+// ParkOrder 订单加入重试队列，失败次数加1
+func (r *defaultReviewRepo) ParkOrder(ctx context.Context, orderID int64) (int, error) {
+	attempts, err := r.data.cache.ZIncrBy(ctx, defaultReviewParkedKey, 1, strconv.FormatInt(orderID, 10)).Result()
+	return int(attempts), err
+}
+
+// ListParkedOrders 按失败次数从少到多返回重试队列中的订单
+func (r *defaultReviewRepo) ListParkedOrders(ctx context.Context, size int) ([]int64, error) {
+	members, err := r.data.cache.ZRange(ctx, defaultReviewParkedKey, 0, int64(size)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	orderIDs := make([]int64, 0, len(members))
+	for _, member := range members {
+		orderID, err := strconv.ParseInt(member, 10, 64)
+		if err != nil {
+			r.log.Warnf("默认评价重试队列订单id无效: %s", member)
+			continue
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	return orderIDs, nil
+}
+
+// UnparkOrder 订单移出重试队列
+func (r *defaultReviewRepo) UnparkOrder(ctx context.Context, orderID int64) error {
+	return r.data.cache.ZRem(ctx, defaultReviewParkedKey, strconv.FormatInt(orderID, 10)).Err()
+}
+
+// DeadLetterOrder 订单移出重试队列并转入死信，保留转入时间便于排查
+func (r *defaultReviewRepo) DeadLetterOrder(ctx context.Context, orderID int64) error {
+	member := strconv.FormatInt(orderID, 10)
+	pipe := r.data.cache.TxPipeline()
+	pipe.ZRem(ctx, defaultReviewParkedKey, member)
+	pipe.ZAdd(ctx, defaultReviewDeadKey, redis.Z{Score: float64(time.Now().Unix()), Member: member})
+	_, err := pipe.Exec(ctx)
+	return err
+}
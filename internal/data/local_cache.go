@@ -0,0 +1,136 @@
+package data
+
+import (
+	"review-service/internal/conf"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// 本地缓存默认过期时间，需远小于redis缓存时间，其他实例上的写入只能靠过期感知
+const (
+	defaultLocalListTTL   = 3 * time.Second
+	defaultLocalDetailTTL = 5 * time.Second
+)
+
+type localEntry struct {
+	val      interface{}
+	expireAt time.Time
+}
+
+// localCache 进程内LRU缓存，位于redis之前，缓存的对象只读不可修改。
+// 为nil时表示未开启，所有方法均可安全调用。
+type localCache struct {
+	lru       *lru.Cache
+	listTTL   time.Duration
+	detailTTL time.Duration
+
+	// 店铺列表本地缓存版本号，本实例写入时取全局递增序号，只保留最近写入的店铺。
+	// 版本号被淘汰的店铺使用淘汰时的序号，保证不会退回到旧版本读到失效的列表
+	mu        sync.Mutex
+	storeGens *lru.Cache
+	genSeq    uint64
+	genFloor  uint64
+}
+
+func newLocalCache(c *conf.Data_LocalCache) (*localCache, error) {
+	if c.GetSize() <= 0 {
+		return nil, nil
+	}
+	l, err := lru.New(int(c.GetSize()))
+	if err != nil {
+		return nil, err
+	}
+	lc := &localCache{
+		lru:       l,
+		listTTL:   defaultLocalListTTL,
+		detailTTL: defaultLocalDetailTTL,
+	}
+	// 淘汰回调在bumpStores持有锁时触发
+	lc.storeGens, err = lru.NewWithEvict(int(c.GetSize()), func(key, value interface{}) {
+		lc.genSeq++
+		lc.genFloor = lc.genSeq
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.GetListTtl() != nil {
+		lc.listTTL = c.GetListTtl().AsDuration()
+	}
+	if c.GetDetailTtl() != nil {
+		lc.detailTTL = c.GetDetailTtl().AsDuration()
+	}
+	return lc, nil
+}
+
+func (c *localCache) get(key string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	v, ok := c.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	entry := v.(*localEntry)
+	if time.Now().After(entry.expireAt) {
+		c.lru.Remove(key)
+		return nil, false
+	}
+	return entry.val, true
+}
+
+func (c *localCache) setList(key string, val interface{}) {
+	if c == nil {
+		return
+	}
+	c.set(key, val, c.listTTL)
+}
+
+func (c *localCache) setDetail(key string, val interface{}) {
+	if c == nil {
+		return
+	}
+	c.set(key, val, c.detailTTL)
+}
+
+func (c *localCache) set(key string, val interface{}, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	c.lru.Add(key, &localEntry{val: val, expireAt: time.Now().Add(ttl)})
+}
+
+func (c *localCache) remove(keys ...string) {
+	if c == nil {
+		return
+	}
+	for _, key := range keys {
+		c.lru.Remove(key)
+	}
+}
+
+func (c *localCache) storeGen(storeID int64) uint64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen, ok := c.storeGens.Get(storeID); ok {
+		return gen.(uint64)
+	}
+	return c.genFloor
+}
+
+// bumpStores 使店铺的本地列表缓存全部失效
+func (c *localCache) bumpStores(storeIDs ...int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, storeID := range storeIDs {
+		c.genSeq++
+		c.storeGens.Add(storeID, c.genSeq)
+	}
+}
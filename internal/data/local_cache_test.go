@@ -0,0 +1,25 @@
+package data
+
+import (
+	"testing"
+
+	"review-service/internal/conf"
+)
+
+// TestStoreGenBounded 店铺版本号数量有上限，被淘汰的店铺不会退回到用过的版本号
+func TestStoreGenBounded(t *testing.T) {
+	c, err := newLocalCache(&conf.Data_LocalCache{Size: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	used := map[uint64]struct{}{c.storeGen(1): {}}
+	c.bumpStores(1)
+	used[c.storeGen(1)] = struct{}{}
+	c.bumpStores(2, 3, 4)
+	if n := c.storeGens.Len(); n != 2 {
+		t.Fatalf("want 2 store gens kept, got %d", n)
+	}
+	if _, ok := used[c.storeGen(1)]; ok {
+		t.Fatalf("evicted store reused gen %d", c.storeGen(1))
+	}
+}
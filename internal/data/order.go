@@ -0,0 +1,125 @@
+package data
+
+import (
+	"context"
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+	"time"
+
+	orderv1 "review-service/api/order/v1"
+
+	"github.com/go-kratos/kratos/contrib/registry/consul/v2"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultOrderServiceEndpoint = "discovery:///order-service"
+
+// NewOrderServiceClient 通过注册中心发现订单服务并建立gRPC连接
+func NewOrderServiceClient(c *conf.Data, r *consul.Registry) (orderv1.OrderClient, func(), error) {
+	endpoint := c.GetOrderService().GetEndpoint()
+	if endpoint == "" {
+		endpoint = defaultOrderServiceEndpoint
+	}
+	opts := []grpc.ClientOption{
+		grpc.WithEndpoint(endpoint),
+		grpc.WithDiscovery(r),
+		grpc.WithMiddleware(recovery.Recovery()),
+	}
+	if timeout := c.GetOrderService().GetTimeout(); timeout != nil {
+		opts = append(opts, grpc.WithTimeout(timeout.AsDuration()))
+	}
+	conn, err := grpc.DialInsecure(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = conn.Close()
+	}
+	return orderv1.NewOrderClient(conn), cleanup, nil
+}
+
+type orderClient struct {
+	client orderv1.OrderClient
+	log    *log.Helper
+}
+
+// NewOrderClient .
+func NewOrderClient(client orderv1.OrderClient, logger log.Logger) biz.OrderClient {
+	return &orderClient{
+		client: client,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// GetOrder 调用订单服务查询订单
+func (c *orderClient) GetOrder(ctx context.Context, orderID int64) (*biz.Order, error) {
+	reply, err := c.client.GetOrder(ctx, &orderv1.GetOrderRequest{OrderId: orderID})
+	if errors.IsNotFound(err) {
+		return nil, biz.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	order := reply.GetOrder()
+	if order == nil {
+		return nil, biz.ErrOrderNotFound
+	}
+	return toBizOrder(order), nil
+}
+
+// ListCompletedOrders 调用订单服务分页拉取已完成的订单
+func (c *orderClient) ListCompletedOrders(ctx context.Context, cursor string, completedBefore time.Time, size int) ([]*biz.Order, string, error) {
+	reply, err := c.client.ListCompletedOrders(ctx, &orderv1.ListCompletedOrdersRequest{
+		Cursor:          cursor,
+		CompletedBefore: timestamppb.New(completedBefore),
+		Size:            int32(size),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	orders := make([]*biz.Order, len(reply.Orders))
+	for i, order := range reply.Orders {
+		orders[i] = toBizOrder(order)
+	}
+	return orders, reply.NextCursor, nil
+}
+
+func toBizOrder(order *orderv1.OrderInfo) *biz.Order {
+	var completeAt time.Time
+	if order.GetCompleteTime() != nil {
+		completeAt = order.GetCompleteTime().AsTime()
+	}
+	items := make([]*biz.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &biz.OrderItem{SkuID: item.SkuId, SpuID: item.SpuId}
+	}
+	return &biz.Order{
+		OrderID:    order.OrderId,
+		UserID:     order.UserId,
+		StoreID:    order.StoreId,
+		Status:     toBizOrderStatus(order.GetStatus()),
+		CompleteAt: completeAt,
+		Items:      items,
+	}
+}
+
+func toBizOrderStatus(status orderv1.OrderStatus) biz.OrderStatus {
+	switch status {
+	case orderv1.OrderStatus_UNPAID:
+		return biz.OrderStatusUnpaid
+	case orderv1.OrderStatus_PAID:
+		return biz.OrderStatusPaid
+	case orderv1.OrderStatus_SHIPPED:
+		return biz.OrderStatusShipped
+	case orderv1.OrderStatus_COMPLETED:
+		return biz.OrderStatusCompleted
+	case orderv1.OrderStatus_CANCELED:
+		return biz.OrderStatusCanceled
+	default:
+		return biz.OrderStatusUnknown
+	}
+}
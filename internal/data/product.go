@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+
+	productv1 "review-service/api/product/v1"
+
+	"github.com/go-kratos/kratos/contrib/registry/consul/v2"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+)
+
+const defaultProductServiceEndpoint = "discovery:///product-service"
+
+// NewProductServiceClient 通过注册中心发现商品服务并建立gRPC连接
+func NewProductServiceClient(c *conf.Data, r *consul.Registry) (productv1.ProductClient, func(), error) {
+	endpoint := c.GetProductService().GetEndpoint()
+	if endpoint == "" {
+		endpoint = defaultProductServiceEndpoint
+	}
+	opts := []grpc.ClientOption{
+		grpc.WithEndpoint(endpoint),
+		grpc.WithDiscovery(r),
+		grpc.WithMiddleware(recovery.Recovery()),
+	}
+	if timeout := c.GetProductService().GetTimeout(); timeout != nil {
+		opts = append(opts, grpc.WithTimeout(timeout.AsDuration()))
+	}
+	conn, err := grpc.DialInsecure(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = conn.Close()
+	}
+	return productv1.NewProductClient(conn), cleanup, nil
+}
+
+type productClient struct {
+	client productv1.ProductClient
+	log    *log.Helper
+}
+
+// NewProductClient .
+func NewProductClient(client productv1.ProductClient, logger log.Logger) biz.ProductClient {
+	return &productClient{
+		client: client,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// GetSku 调用商品服务查询SKU
+func (c *productClient) GetSku(ctx context.Context, skuID int64) (*biz.Sku, error) {
+	reply, err := c.client.GetSku(ctx, &productv1.GetSkuRequest{SkuId: skuID})
+	if errors.IsNotFound(err) {
+		return nil, biz.ErrSkuNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	sku := reply.GetSku()
+	if sku == nil {
+		return nil, biz.ErrSkuNotFound
+	}
+	attrs := make([]*biz.SkuAttr, len(sku.Attrs))
+	for i, attr := range sku.Attrs {
+		attrs[i] = &biz.SkuAttr{Name: attr.Name, Value: attr.Value}
+	}
+	return &biz.Sku{
+		SkuID: sku.SkuId,
+		SpuID: sku.SpuId,
+		Title: sku.Title,
+		Attrs: attrs,
+		Image: sku.Image,
+		Price: sku.Price,
+	}, nil
+}
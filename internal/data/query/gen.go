@@ -18,23 +18,35 @@ import (
 var (
 	Q                = new(Query)
 	ReviewAppealInfo *reviewAppealInfo
+	ReviewAppendInfo *reviewAppendInfo
+	ReviewEsOutbox   *reviewEsOutbox
 	ReviewInfo       *reviewInfo
 	ReviewReplyInfo  *reviewReplyInfo
+	SpuRatingStat    *spuRatingStat
+	StoreRatingStat  *storeRatingStat
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	*Q = *Use(db, opts...)
 	ReviewAppealInfo = &Q.ReviewAppealInfo
+	ReviewAppendInfo = &Q.ReviewAppendInfo
+	ReviewEsOutbox = &Q.ReviewEsOutbox
 	ReviewInfo = &Q.ReviewInfo
 	ReviewReplyInfo = &Q.ReviewReplyInfo
+	SpuRatingStat = &Q.SpuRatingStat
+	StoreRatingStat = &Q.StoreRatingStat
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
 		db:               db,
 		ReviewAppealInfo: newReviewAppealInfo(db, opts...),
+		ReviewAppendInfo: newReviewAppendInfo(db, opts...),
+		ReviewEsOutbox:   newReviewEsOutbox(db, opts...),
 		ReviewInfo:       newReviewInfo(db, opts...),
 		ReviewReplyInfo:  newReviewReplyInfo(db, opts...),
+		SpuRatingStat:    newSpuRatingStat(db, opts...),
+		StoreRatingStat:  newStoreRatingStat(db, opts...),
 	}
 }
 
@@ -42,8 +54,12 @@ type Query struct {
 	db *gorm.DB
 
 	ReviewAppealInfo reviewAppealInfo
+	ReviewAppendInfo reviewAppendInfo
+	ReviewEsOutbox   reviewEsOutbox
 	ReviewInfo       reviewInfo
 	ReviewReplyInfo  reviewReplyInfo
+	SpuRatingStat    spuRatingStat
+	StoreRatingStat  storeRatingStat
 }
 
 func (q *Query) Available() bool { return q.db != nil }
@@ -52,8 +68,12 @@ func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
 		db:               db,
 		ReviewAppealInfo: q.ReviewAppealInfo.clone(db),
+		ReviewAppendInfo: q.ReviewAppendInfo.clone(db),
+		ReviewEsOutbox:   q.ReviewEsOutbox.clone(db),
 		ReviewInfo:       q.ReviewInfo.clone(db),
 		ReviewReplyInfo:  q.ReviewReplyInfo.clone(db),
+		SpuRatingStat:    q.SpuRatingStat.clone(db),
+		StoreRatingStat:  q.StoreRatingStat.clone(db),
 	}
 }
 
@@ -69,22 +89,34 @@ func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
 		db:               db,
 		ReviewAppealInfo: q.ReviewAppealInfo.replaceDB(db),
+		ReviewAppendInfo: q.ReviewAppendInfo.replaceDB(db),
+		ReviewEsOutbox:   q.ReviewEsOutbox.replaceDB(db),
 		ReviewInfo:       q.ReviewInfo.replaceDB(db),
 		ReviewReplyInfo:  q.ReviewReplyInfo.replaceDB(db),
+		SpuRatingStat:    q.SpuRatingStat.replaceDB(db),
+		StoreRatingStat:  q.StoreRatingStat.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
 	ReviewAppealInfo IReviewAppealInfoDo
+	ReviewAppendInfo IReviewAppendInfoDo
+	ReviewEsOutbox   IReviewEsOutboxDo
 	ReviewInfo       IReviewInfoDo
 	ReviewReplyInfo  IReviewReplyInfoDo
+	SpuRatingStat    ISpuRatingStatDo
+	StoreRatingStat  IStoreRatingStatDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
 		ReviewAppealInfo: q.ReviewAppealInfo.WithContext(ctx),
+		ReviewAppendInfo: q.ReviewAppendInfo.WithContext(ctx),
+		ReviewEsOutbox:   q.ReviewEsOutbox.WithContext(ctx),
 		ReviewInfo:       q.ReviewInfo.WithContext(ctx),
 		ReviewReplyInfo:  q.ReviewReplyInfo.WithContext(ctx),
+		SpuRatingStat:    q.SpuRatingStat.WithContext(ctx),
+		StoreRatingStat:  q.StoreRatingStat.WithContext(ctx),
 	}
 }
 
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"review-service/internal/biz"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// GetStoreRatingSummary 获取店铺评分汇总，优先读缓存，缓存key带店铺缓存版本号，评论变更后自动失效
+func (r *reviewRepo) GetStoreRatingSummary(ctx context.Context, storeID int64) (*biz.StoreRatingSummary, error) {
+	gen, err := r.data.getStoreCacheGen(ctx, storeID)
+	if err != nil {
+		r.log.WithContext(ctx).Warnf("获取店铺评论缓存版本失败，降级查库: %v", err)
+		return r.getStoreRatingSummaryFromDB(ctx, storeID)
+	}
+	key := fmt.Sprintf("review:store:%d:g%d:summary", storeID, gen)
+	val, err, _ := g.Do(key, func() (interface{}, error) {
+		// 1. 先从缓存查
+		data, err := r.getDataFromRedis(ctx, key)
+		if err == nil {
+			summary := &biz.StoreRatingSummary{}
+			if err := json.Unmarshal(data, summary); err == nil {
+				return summary, nil
+			}
+		} else if !errors.Is(err, redis.Nil) {
+			r.log.WithContext(ctx).Warnf("查询评分汇总缓存失败: %v", err)
+		}
+
+		// 2. 未命中缓存，查统计表并回写缓存
+		summary, err := r.getStoreRatingSummaryFromDB(ctx, storeID)
+		if err != nil {
+			return nil, err
+		}
+		if data, err := json.Marshal(summary); err == nil {
+			if err := r.setDataToRedis(ctx, key, data, r.data.cachePolicy.listTTL); err != nil {
+				r.log.WithContext(ctx).Warnf("写入评分汇总缓存失败: %v", err)
+			}
+		}
+		return summary, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*biz.StoreRatingSummary), nil
+}
+
+// getStoreRatingSummaryFromDB 根据店铺评分统计表计算评分汇总，店铺没有统计数据时返回全0
+func (r *reviewRepo) getStoreRatingSummaryFromDB(ctx context.Context, storeID int64) (*biz.StoreRatingSummary, error) {
+	stat := r.data.query.StoreRatingStat
+	row, err := stat.WithContext(ctx).Where(stat.StoreID.Eq(storeID)).First()
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &biz.StoreRatingSummary{StoreID: storeID}, nil
+	}
+	if err != nil {
+		r.log.Errorf("查询店铺评分统计失败: %v", err)
+		return nil, err
+	}
+
+	summary := &biz.StoreRatingSummary{
+		StoreID:     storeID,
+		Total:       row.ReviewCount,
+		ScoreCounts: [5]int64{row.Score1Count, row.Score2Count, row.Score3Count, row.Score4Count, row.Score5Count},
+	}
+	if row.ReviewCount <= 0 {
+		return summary, nil
+	}
+	total := float64(row.ReviewCount)
+	summary.AvgScore = round2(float64(row.ScoreSum) / total)
+	summary.AvgServiceScore = round2(float64(row.ServiceScoreSum) / total)
+	summary.AvgExpressScore = round2(float64(row.ExpressScoreSum) / total)
+	summary.MediaRate = round2(float64(row.MediaCount) * 100 / total)
+	summary.ReplyRate = round2(float64(row.ReplyCount) * 100 / total)
+	return summary, nil
+}
+
+// round2 保留两位小数
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
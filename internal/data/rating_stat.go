@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ratingDelta 评分统计增量
+type ratingDelta struct {
+	count, scoreSum, serviceScoreSum, expressScoreSum int64
+	scoreCounts                                       [5]int64
+	mediaCount, replyCount                            int64
+}
+
+// reviewRatingDelta 一条评论对评分统计的贡献，sign为1计入、-1移出
+func reviewRatingDelta(review *model.ReviewInfo, sign int64) ratingDelta {
+	d := ratingDelta{
+		count:           sign,
+		scoreSum:        sign * int64(review.Score),
+		serviceScoreSum: sign * int64(review.ServiceScore),
+		expressScoreSum: sign * int64(review.ExpressScore),
+		mediaCount:      sign * int64(review.HasMedia),
+		replyCount:      sign * int64(review.HasReply),
+	}
+	if review.Score >= 1 && review.Score <= 5 {
+		d.scoreCounts[review.Score-1] = sign
+	}
+	return d
+}
+
+// assignments 生成累加的更新语句
+func (d ratingDelta) assignments() clause.Set {
+	values := map[string]interface{}{
+		"review_count":      d.count,
+		"score_sum":         d.scoreSum,
+		"service_score_sum": d.serviceScoreSum,
+		"express_score_sum": d.expressScoreSum,
+		"media_count":       d.mediaCount,
+		"reply_count":       d.replyCount,
+	}
+	for i, n := range d.scoreCounts {
+		values[fmt.Sprintf("score%d_count", i+1)] = n
+	}
+	set := clause.Set{{Column: clause.Column{Name: "update_at"}, Value: gorm.Expr("CURRENT_TIMESTAMP")}}
+	for column, n := range values {
+		if n == 0 {
+			continue
+		}
+		set = append(set, clause.Assignment{Column: clause.Column{Name: column}, Value: gorm.Expr(column+" + ?", n)})
+	}
+	return set
+}
+
+// applyRatingStat 在事务中增量更新店铺和商品的评分统计，统计行不存在时插入。
+// 依赖store_rating_stat.store_id、spu_rating_stat.spu_id上的唯一索引。
+func applyRatingStat(ctx context.Context, tx *query.Query, storeID, spuID int64, d ratingDelta) error {
+	storeStat := &model.StoreRatingStat{
+		StoreID:         storeID,
+		ReviewCount:     d.count,
+		ScoreSum:        d.scoreSum,
+		ServiceScoreSum: d.serviceScoreSum,
+		ExpressScoreSum: d.expressScoreSum,
+		Score1Count:     d.scoreCounts[0],
+		Score2Count:     d.scoreCounts[1],
+		Score3Count:     d.scoreCounts[2],
+		Score4Count:     d.scoreCounts[3],
+		Score5Count:     d.scoreCounts[4],
+		MediaCount:      d.mediaCount,
+		ReplyCount:      d.replyCount,
+	}
+	err := tx.StoreRatingStat.WithContext(ctx).
+		Clauses(clause.OnConflict{DoUpdates: d.assignments()}).
+		Create(storeStat)
+	if err != nil {
+		return err
+	}
+	if spuID <= 0 {
+		return nil
+	}
+	spuStat := &model.SpuRatingStat{
+		SpuID:           spuID,
+		ReviewCount:     d.count,
+		ScoreSum:        d.scoreSum,
+		ServiceScoreSum: d.serviceScoreSum,
+		ExpressScoreSum: d.expressScoreSum,
+		Score1Count:     d.scoreCounts[0],
+		Score2Count:     d.scoreCounts[1],
+		Score3Count:     d.scoreCounts[2],
+		Score4Count:     d.scoreCounts[3],
+		Score5Count:     d.scoreCounts[4],
+		MediaCount:      d.mediaCount,
+		ReplyCount:      d.replyCount,
+	}
+	return tx.SpuRatingStat.WithContext(ctx).
+		Clauses(clause.OnConflict{DoUpdates: d.assignments()}).
+		Create(spuStat)
+}
+
+// applyReviewStatusStat 评论状态变更时更新评分统计，只有进出审核通过状态才影响统计
+func applyReviewStatusStat(ctx context.Context, tx *query.Query, review *model.ReviewInfo, from, to int32) error {
+	var sign int64
+	switch {
+	case from != biz.ReviewStatusApproved && to == biz.ReviewStatusApproved:
+		sign = 1
+	case from == biz.ReviewStatusApproved && to != biz.ReviewStatusApproved:
+		sign = -1
+	default:
+		return nil
+	}
+	return applyRatingStat(ctx, tx, review.StoreID, review.SpuID, reviewRatingDelta(review, sign))
+}
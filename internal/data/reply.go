@@ -0,0 +1,147 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"time"
+)
+
+// GetReplyByReplyID 根据回复ID获取回复，已删除的回复视为不存在
+func (r *reviewRepo) GetReplyByReplyID(ctx context.Context, replyID int64) (*model.ReviewReplyInfo, error) {
+	reviewReply := r.data.query.ReviewReplyInfo
+	return reviewReply.WithContext(ctx).
+		Where(reviewReply.ReplyID.Eq(replyID), reviewReply.DeleteAt.IsNull()).
+		First()
+}
+
+// UpdateReply 商家修改回复内容，按版本号更新防止并发覆盖，版本不一致时返回biz.ErrReviewVersionConflict
+func (r *reviewRepo) UpdateReply(ctx context.Context, reply *model.ReviewReplyInfo, update *biz.ReviewReply) error {
+	reviewReply := r.data.query.ReviewReplyInfo
+	updateRes, err := reviewReply.WithContext(ctx).
+		Where(
+			reviewReply.ReplyID.Eq(reply.ReplyID),
+			reviewReply.Version.Eq(reply.Version),
+			reviewReply.DeleteAt.IsNull(),
+		).
+		UpdateSimple(
+			reviewReply.Content.Value(update.Content),
+			reviewReply.PicInfo.Value(update.PicInfo),
+			reviewReply.VideoInfo.Value(update.VideoInfo),
+			reviewReply.Version.Add(1),
+		)
+	if err != nil {
+		return err
+	}
+	if updateRes.RowsAffected == 0 {
+		return biz.ErrReviewVersionConflict
+	}
+	if err := r.data.delReviewDetailCache(ctx, reply.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	return nil
+}
+
+// DeleteReply 商家删除回复，逻辑删除回复并将评论恢复为未回复
+func (r *reviewRepo) DeleteReply(ctx context.Context, reply *model.ReviewReplyInfo) error {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		// 1.回复表逻辑删除
+		updateRes, err := tx.ReviewReplyInfo.WithContext(ctx).
+			Where(tx.ReviewReplyInfo.ReplyID.Eq(reply.ReplyID), tx.ReviewReplyInfo.DeleteAt.IsNull()).
+			UpdateSimple(
+				tx.ReviewReplyInfo.DeleteAt.Value(time.Now()),
+				tx.ReviewReplyInfo.Version.Add(1),
+			)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return errors.New("删除回复失败")
+		}
+
+		// 2.评论表重置回复状态
+		updateRes, err = tx.ReviewInfo.WithContext(ctx).
+			Where(tx.ReviewInfo.ReviewID.Eq(reply.ReviewID), tx.ReviewInfo.HasReply.Eq(1)).
+			UpdateColumn(tx.ReviewInfo.HasReply, 0)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return nil
+		}
+
+		// 3.审核通过且未删除的评论移出回复数统计，已删除的评论在删除时已整体移出
+		review, err := tx.ReviewInfo.WithContext(ctx).Where(tx.ReviewInfo.ReviewID.Eq(reply.ReviewID)).First()
+		if err != nil {
+			return err
+		}
+		if review.Status == biz.ReviewStatusApproved && review.DeleteAt == nil {
+			if err := applyRatingStat(ctx, tx, review.StoreID, review.SpuID, ratingDelta{replyCount: -1}); err != nil {
+				return err
+			}
+		}
+
+		// 4.写入ES同步事件
+		return addIndexEvent(ctx, tx, reply.ReviewID)
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.data.delReviewDetailCache(ctx, reply.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	if err := r.data.bumpStoreCacheGen(ctx, reply.StoreID); err != nil {
+		r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
+	}
+	return nil
+}
+
+// ListRepliesByStoreID 按回复ID倒序分页查询店铺的回复，并带上对应的评论，已删除评论的回复不展示
+func (r *reviewRepo) ListRepliesByStoreID(ctx context.Context, param *biz.ListRepliesByStoreParam) ([]*biz.ReviewDetail, error) {
+	q := r.data.query
+	do := q.ReviewReplyInfo.WithContext(ctx).
+		Select(q.ReviewReplyInfo.ALL).
+		Join(q.ReviewInfo, q.ReviewInfo.ReviewID.EqCol(q.ReviewReplyInfo.ReviewID)).
+		Where(
+			q.ReviewReplyInfo.StoreID.Eq(param.StoreID),
+			q.ReviewReplyInfo.DeleteAt.IsNull(),
+			q.ReviewInfo.DeleteAt.IsNull(),
+		)
+	if param.Cursor > 0 {
+		do = do.Where(q.ReviewReplyInfo.ReplyID.Lt(param.Cursor))
+	}
+	replies, err := do.Order(q.ReviewReplyInfo.ReplyID.Desc()).Limit(int(param.Size)).Find()
+	if err != nil {
+		return nil, err
+	}
+	if len(replies) == 0 {
+		return []*biz.ReviewDetail{}, nil
+	}
+
+	reviewIDs := make([]int64, len(replies))
+	for i, reply := range replies {
+		reviewIDs[i] = reply.ReviewID
+	}
+	reviews, err := q.ReviewInfo.WithContext(ctx).Where(q.ReviewInfo.ReviewID.In(reviewIDs...)).Find()
+	if err != nil {
+		return nil, err
+	}
+	reviewMap := make(map[int64]*model.ReviewInfo, len(reviews))
+	for _, review := range reviews {
+		reviewMap[review.ReviewID] = review
+	}
+	// 与评论详情一致，只展示审核通过的追评
+	appends, err := r.data.getReviewAppends(ctx, reviewIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	details := make([]*biz.ReviewDetail, 0, len(replies))
+	for _, reply := range replies {
+		if review, ok := reviewMap[reply.ReviewID]; ok {
+			details = append(details, &biz.ReviewDetail{Review: review, Reply: reply, Append: appends[reply.ReviewID]})
+		}
+	}
+	return details, nil
+}
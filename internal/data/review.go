@@ -1,19 +1,29 @@
 package data
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"review-service/internal/biz"
 	"review-service/internal/data/model"
 	"review-service/internal/data/query"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/elastic/go-elasticsearch/v9/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/sortorder"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/redis/go-redis/v9"
 	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
 )
 
 type reviewRepo struct {
@@ -31,20 +41,72 @@ func NewReviewRepo(data *Data, logger log.Logger) biz.ReviewRepo {
 
 // SaveReview 创建评论
 func (r *reviewRepo) SaveReview(ctx context.Context, review *model.ReviewInfo) (int64, error) {
-	if err := r.data.query.ReviewInfo.WithContext(ctx).Create(review); err != nil {
+	reviewIDs, err := r.BatchSaveReviews(ctx, []*model.ReviewInfo{review})
+	if err != nil {
 		return 0, err
 	}
-	return review.ReviewID, nil
+	return reviewIDs[0], nil
 }
 
-// GetReviewByOrderID 根据订单ID获取评论
-func (r *reviewRepo) GetReviewByOrderID(ctx context.Context, orderID int64) (*model.ReviewInfo, error) {
-	reviewInfo := r.data.query.ReviewInfo
-	review, err := reviewInfo.WithContext(ctx).Where(reviewInfo.OrderID.Eq(orderID)).First()
+// BatchSaveReviews 在一个事务中创建多条评论，
+// review_info上(order_id, sku_id)的唯一索引uk_order_sku兜底并发重复提交，冲突时返回biz.ErrReviewRepeated，
+// 索引见migrations/20261018_review_info_uk_order_sku.sql，软删除的评论仍占用该索引
+func (r *reviewRepo) BatchSaveReviews(ctx context.Context, reviews []*model.ReviewInfo) ([]int64, error) {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		if err := tx.ReviewInfo.WithContext(ctx).Create(reviews...); err != nil {
+			return err
+		}
+		reviewIDs := make([]int64, len(reviews))
+		for i, review := range reviews {
+			reviewIDs[i] = review.ReviewID
+			if review.Status == biz.ReviewStatusApproved {
+				if err := applyRatingStat(ctx, tx, review.StoreID, review.SpuID, reviewRatingDelta(review, 1)); err != nil {
+					return err
+				}
+			}
+		}
+		return addIndexEvent(ctx, tx, reviewIDs...)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, biz.ErrReviewRepeated
+	}
 	if err != nil {
 		return nil, err
 	}
-	return review, nil
+
+	reviewIDs := make([]int64, len(reviews))
+	storeIDs := make([]int64, 0, 1)
+	seen := make(map[int64]struct{}, 1)
+	for i, review := range reviews {
+		reviewIDs[i] = review.ReviewID
+		if _, ok := seen[review.StoreID]; !ok {
+			seen[review.StoreID] = struct{}{}
+			storeIDs = append(storeIDs, review.StoreID)
+		}
+	}
+	if err := r.data.bumpStoreCacheGen(ctx, storeIDs...); err != nil {
+		r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
+	}
+	return reviewIDs, nil
+}
+
+// GetReviewByOrderID 根据订单ID获取订单下所有商品的评论
+func (r *reviewRepo) GetReviewByOrderID(ctx context.Context, orderID int64) ([]*model.ReviewInfo, error) {
+	reviewInfo := r.data.query.ReviewInfo
+	return reviewInfo.WithContext(ctx).
+		Where(reviewInfo.OrderID.Eq(orderID), reviewInfo.DeleteAt.IsNull()).
+		Order(reviewInfo.ID).
+		Find()
+}
+
+// ListReviewedSkuIDs 返回订单下已有评论的商品，已删除的评论仍占用uk_order_sku，也算已评价
+func (r *reviewRepo) ListReviewedSkuIDs(ctx context.Context, orderID int64) ([]int64, error) {
+	reviewInfo := r.data.query.ReviewInfo
+	var skuIDs []int64
+	err := reviewInfo.WithContext(ctx).
+		Where(reviewInfo.OrderID.Eq(orderID)).
+		Pluck(reviewInfo.SkuID, &skuIDs)
+	return skuIDs, err
 }
 
 // ReplyReview 商家回复评论
@@ -65,112 +127,841 @@ func (r *reviewRepo) ReplyReview(ctx context.Context, reply *biz.ReviewReply) (i
 			return err
 		}
 
-		// 2.评论表更新回复状态
+		// 2.评论表更新回复状态，只有未回复的评论能更新成功，并发回复时只有一个事务提交
 		updateRes, err := tx.ReviewInfo.WithContext(ctx).
-			Where(tx.ReviewInfo.ReviewID.Eq(reply.ReviewID)).
+			Where(tx.ReviewInfo.ReviewID.Eq(reply.ReviewID), tx.ReviewInfo.HasReply.Eq(0), tx.ReviewInfo.DeleteAt.IsNull()).
 			UpdateColumn(tx.ReviewInfo.HasReply, 1)
 
 		if err != nil {
 			return err
 		}
 		if updateRes.RowsAffected == 0 {
-			return errors.New("更新评论已回复失败")
+			return biz.ErrReviewHasReply
 		}
-		return nil
+
+		// 3.审核通过的评论计入回复数统计
+		review, err := tx.ReviewInfo.WithContext(ctx).Where(tx.ReviewInfo.ReviewID.Eq(reply.ReviewID)).First()
+		if err != nil {
+			return err
+		}
+		if review.Status == biz.ReviewStatusApproved {
+			if err := applyRatingStat(ctx, tx, review.StoreID, review.SpuID, ratingDelta{replyCount: 1}); err != nil {
+				return err
+			}
+		}
+
+		// 4.写入ES同步事件
+		return addIndexEvent(ctx, tx, reply.ReviewID)
 	})
 
 	if err != nil {
 		return 0, err
 	}
+	if err := r.data.delReviewDetailCache(ctx, reply.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	if err := r.data.bumpStoreCacheGen(ctx, reply.StoreID); err != nil {
+		r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
+	}
 
 	return reviewReply.ReplyID, nil
 }
 
-// GetReviewByReviewID 根据评论ID获取评论
+// GetReviewByReviewID 根据评论ID获取评论，已删除的评论视为不存在
 func (r *reviewRepo) GetReviewByReviewID(ctx context.Context, reviewID int64) (*model.ReviewInfo, error) {
 	review := r.data.query.ReviewInfo
-	rv, err := review.WithContext(ctx).Where(review.ReviewID.Eq(reviewID)).First()
+	rv, err := review.WithContext(ctx).Where(review.ReviewID.Eq(reviewID), review.DeleteAt.IsNull()).First()
 	if err != nil {
 		return nil, err
 	}
 	return rv, nil
 }
 
-// GetReviewListByStoreID 根据店铺ID获取评论列表
-func (r *reviewRepo) GetReviewListByStoreID(ctx context.Context, storeID int64, offset int32, size int32) ([]*biz.ReviewInfo, error) {
+// GetReviewListByStoreID 根据店铺ID和筛选条件获取评论列表
+func (r *reviewRepo) GetReviewListByStoreID(ctx context.Context, storeID int64, filter *biz.ReviewListFilter, page *biz.ReviewPage) (*biz.ReviewListResult, error) {
+	result, err := r.searchReviews(ctx, buildStoreReviewQuery(storeID, filter), reviewSorts(filter.SortBy), page)
+	if err != nil {
+		r.log.Errorf("根据店铺ID获取评论列表失败: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// buildStoreReviewQuery 根据筛选条件构造店铺评论的bool查询
+func buildStoreReviewQuery(storeID int64, filter *biz.ReviewListFilter) *types.Query {
+	filters := []types.Query{
+		{Term: map[string]types.TermQuery{"store_id": {Value: storeID}}},
+		{Term: map[string]types.TermQuery{"status": {Value: biz.ReviewStatusApproved}}},
+	}
+	if filter.MinScore > 0 || filter.MaxScore > 0 {
+		scoreRange := types.NumberRangeQuery{}
+		if filter.MinScore > 0 {
+			gte := types.Float64(filter.MinScore)
+			scoreRange.Gte = &gte
+		}
+		if filter.MaxScore > 0 {
+			lte := types.Float64(filter.MaxScore)
+			scoreRange.Lte = &lte
+		}
+		filters = append(filters, types.Query{Range: map[string]types.RangeQuery{"score": scoreRange}})
+	}
+	if filter.HasMedia {
+		filters = append(filters, types.Query{Term: map[string]types.TermQuery{"has_media": {Value: 1}}})
+	}
+	if filter.HasReply {
+		filters = append(filters, types.Query{Term: map[string]types.TermQuery{"has_reply": {Value: 1}}})
+	}
+	var mustNot []types.Query
+	if filter.HideDefault {
+		mustNot = append(mustNot, types.Query{Term: map[string]types.TermQuery{"is_default": {Value: 1}}})
+	}
+	for _, tag := range filter.Tags {
+		filters = append(filters, types.Query{MatchPhrase: map[string]types.MatchPhraseQuery{"tags": {Query: tag}}})
+	}
+	if !filter.StartTime.IsZero() || !filter.EndTime.IsZero() {
+		format := "yyyy-MM-dd HH:mm:ss"
+		timeRange := types.DateRangeQuery{Format: &format}
+		if !filter.StartTime.IsZero() {
+			gte := filter.StartTime.Format(time.DateTime)
+			timeRange.Gte = &gte
+		}
+		if !filter.EndTime.IsZero() {
+			lte := filter.EndTime.Format(time.DateTime)
+			timeRange.Lte = &lte
+		}
+		filters = append(filters, types.Query{Range: map[string]types.RangeQuery{"create_at": timeRange}})
+	}
+	return &types.Query{
+		Bool: &types.BoolQuery{Filter: filters, MustNot: mustNot},
+	}
+}
+
+// reviewSorts 评论列表排序，最后按review_id倒序保证顺序稳定
+func reviewSorts(sortBy biz.ReviewSortBy) []types.SortCombinations {
+	field := func(name string, order sortorder.SortOrder) types.SortCombinations {
+		return types.SortOptions{SortOptions: map[string]types.FieldSort{name: {Order: &order}}}
+	}
+	var sorts []types.SortCombinations
+	switch sortBy {
+	case biz.ReviewSortScoreDesc:
+		sorts = append(sorts, field("score", sortorder.Desc), field("create_at", sortorder.Desc))
+	case biz.ReviewSortScoreAsc:
+		sorts = append(sorts, field("score", sortorder.Asc), field("create_at", sortorder.Desc))
+	case biz.ReviewSortMostHelpful:
+		sorts = append(sorts, field("has_media", sortorder.Desc), field("is_default", sortorder.Asc), field("score", sortorder.Desc), field("create_at", sortorder.Desc))
+	default:
+		sorts = append(sorts, field("create_at", sortorder.Desc))
+	}
+	return append(sorts, field("review_id", sortorder.Desc))
+}
+
+// ListReviewsBySpuID 根据商品SPU获取审核通过的评论列表，skuID大于0时只查该SKU
+func (r *reviewRepo) ListReviewsBySpuID(ctx context.Context, spuID int64, skuID int64, page *biz.ReviewPage) (*biz.ReviewListResult, error) {
+	filter := []types.Query{
+		{Term: map[string]types.TermQuery{"spu_id": {Value: spuID}}},
+		{Term: map[string]types.TermQuery{"status": {Value: biz.ReviewStatusApproved}}},
+	}
+	if skuID > 0 {
+		filter = append(filter, types.Query{Term: map[string]types.TermQuery{"sku_id": {Value: skuID}}})
+	}
+	result, err := r.searchReviews(ctx, &types.Query{
+		Bool: &types.BoolQuery{Filter: filter},
+	}, reviewSorts(biz.ReviewSortNewest), page)
+	if err != nil {
+		r.log.Errorf("根据商品ID获取评论列表失败: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// SearchReviews 按关键词全文检索评论内容和标签，只返回审核通过的评论
+func (r *reviewRepo) SearchReviews(ctx context.Context, param *biz.ReviewSearchParam, offset int32, size int32) ([]*biz.ReviewSearchHit, int64, error) {
+	filter := []types.Query{
+		{Term: map[string]types.TermQuery{"status": {Value: biz.ReviewStatusApproved}}},
+	}
+	if param.StoreID > 0 {
+		filter = append(filter, types.Query{Term: map[string]types.TermQuery{"store_id": {Value: param.StoreID}}})
+	}
+	if param.SpuID > 0 {
+		filter = append(filter, types.Query{Term: map[string]types.TermQuery{"spu_id": {Value: param.SpuID}}})
+	}
 	resp, err := r.data.esClient.Search().
-		Index("review").
+		Index(ReviewIndexAlias).
 		Query(&types.Query{
-			Term: map[string]types.TermQuery{
-				"store_id": {Value: storeID},
+			Bool: &types.BoolQuery{
+				Must: []types.Query{
+					{MultiMatch: &types.MultiMatchQuery{Query: param.Keyword, Fields: []string{"content", "tags"}}},
+				},
+				Filter: filter,
+				// 系统默认评价没有用户填写的内容，不参与搜索
+				MustNot: []types.Query{{Term: map[string]types.TermQuery{"is_default": {Value: 1}}}},
 			},
 		}).
+		Highlight(&types.Highlight{
+			Fields:   []map[string]types.HighlightField{{"content": {}}},
+			PreTags:  []string{"<em>"},
+			PostTags: []string{"</em>"},
+		}).
 		Header("Content-Type", "application/json").
 		Header("Accept", "application/json").
 		From(int(offset)).
 		Size(int(size)).
 		Do(ctx)
 	if err != nil {
-		r.log.Errorf("根据店铺ID获取评论列表失败: %v", err)
+		r.log.Errorf("搜索评论失败: %v", err)
+		return nil, 0, err
+	}
+	hits := make([]*biz.ReviewSearchHit, 0, len(resp.Hits.Hits))
+	for _, hit := range resp.Hits.Hits {
+		var review biz.ReviewInfo
+		if err := json.Unmarshal(hit.Source_, &review); err != nil {
+			r.log.Errorf("解析评论失败: %v", err)
+			continue
+		}
+		hits = append(hits, &biz.ReviewSearchHit{Review: &review, Highlights: hit.Highlight["content"]})
+	}
+	var total int64
+	if resp.Hits.Total != nil {
+		total = resp.Hits.Total.Value
+	}
+	return hits, total, nil
+}
+
+// searchReviews 在review索引中分页查询评论，有游标时使用search_after翻页
+func (r *reviewRepo) searchReviews(ctx context.Context, query *types.Query, sorts []types.SortCombinations, page *biz.ReviewPage) (*biz.ReviewListResult, error) {
+	size := int(page.Size)
+	req := &search.Request{
+		Query:          query,
+		Sort:           sorts,
+		Size:           &size,
+		TrackTotalHits: true,
+	}
+	if page.PageToken != "" {
+		after, err := decodePageToken(page.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		req.SearchAfter = after
+	} else {
+		from := int(page.Offset)
+		req.From = &from
+	}
+	resp, err := r.data.esClient.Search().
+		Index(ReviewIndexAlias).
+		Request(req).
+		Header("Content-Type", "application/json").
+		Header("Accept", "application/json").
+		Do(ctx)
+	if err != nil {
 		return nil, err
 	}
-	reviews := make([]*biz.ReviewInfo, resp.Hits.Total.Value)
+	result := &biz.ReviewListResult{List: make([]*biz.ReviewInfo, 0, len(resp.Hits.Hits))}
 	// 遍历hits，解析评论
-	for i, hit := range resp.Hits.Hits {
+	for _, hit := range resp.Hits.Hits {
 		var review biz.ReviewInfo
 		err = json.Unmarshal(hit.Source_, &review)
 		if err != nil {
 			r.log.Errorf("解析评论失败: %v", err)
 			continue
 		}
-		reviews[i] = &review
+		result.List = append(result.List, &review)
+	}
+	if resp.Hits.Total != nil {
+		result.Total = resp.Hits.Total.Value
+	}
+	// 本页取满说明可能还有下一页，用最后一条的排序值作为游标
+	if n := len(resp.Hits.Hits); n > 0 && n == size {
+		if result.NextPageToken, err = encodePageToken(resp.Hits.Hits[n-1].Sort); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// encodePageToken 将es排序值编码为不透明的分页游标
+func encodePageToken(sortValues []types.FieldValue) (string, error) {
+	data, err := json.Marshal(sortValues)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken 解析分页游标为search_after参数
+func decodePageToken(token string) ([]types.FieldValue, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, biz.ErrInvalidPageToken
+	}
+	var after []types.FieldValue
+	if err := json.Unmarshal(data, &after); err != nil || len(after) == 0 {
+		return nil, biz.ErrInvalidPageToken
+	}
+	return after, nil
+}
+
+// AuditReview 运营审核评论，from为评论当前状态，防止并发审核覆盖
+func (r *reviewRepo) AuditReview(ctx context.Context, audit *biz.AuditReview, from int32) error {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		updateRes, err := tx.ReviewInfo.WithContext(ctx).
+			Where(tx.ReviewInfo.ReviewID.Eq(audit.ReviewID), tx.ReviewInfo.Status.Eq(from), tx.ReviewInfo.DeleteAt.IsNull()).
+			UpdateSimple(
+				tx.ReviewInfo.Status.Value(audit.Status),
+				tx.ReviewInfo.OpReason.Value(audit.OpReason),
+				tx.ReviewInfo.OpRemarks.Value(audit.OpRemarks),
+				tx.ReviewInfo.OpUser.Value(audit.OpUser),
+			)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return biz.ErrReviewStatusChanged
+		}
+		review, err := tx.ReviewInfo.WithContext(ctx).Where(tx.ReviewInfo.ReviewID.Eq(audit.ReviewID)).First()
+		if err != nil {
+			return err
+		}
+		if err := applyReviewStatusStat(ctx, tx, review, from, audit.Status); err != nil {
+			return err
+		}
+		return addIndexEvent(ctx, tx, audit.ReviewID)
+	})
+	if err != nil {
+		return err
+	}
+	if err := r.data.delReviewDetailCache(ctx, audit.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	if err := r.data.bumpStoreCacheGenByReviewID(ctx, audit.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
+	}
+	return nil
+}
+
+// UpdateReview 用户修改评论，按版本号和当前状态更新，修改后重新进入待审核，返回新的版本号
+func (r *reviewRepo) UpdateReview(ctx context.Context, review *model.ReviewInfo, u *biz.UpdateReview) (int32, error) {
+	hasMedia := biz.ReviewHasMedia(u.PicInfo, u.VideoInfo)
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		updateRes, err := tx.ReviewInfo.WithContext(ctx).
+			Where(
+				tx.ReviewInfo.ReviewID.Eq(review.ReviewID),
+				tx.ReviewInfo.Version.Eq(u.Version),
+				tx.ReviewInfo.Status.Eq(review.Status),
+				tx.ReviewInfo.DeleteAt.IsNull(),
+			).
+			UpdateSimple(
+				tx.ReviewInfo.Content.Value(u.Content),
+				tx.ReviewInfo.PicInfo.Value(u.PicInfo),
+				tx.ReviewInfo.VideoInfo.Value(u.VideoInfo),
+				tx.ReviewInfo.Score.Value(u.Score),
+				tx.ReviewInfo.ServiceScore.Value(u.ServiceScore),
+				tx.ReviewInfo.ExpressScore.Value(u.ExpressScore),
+				tx.ReviewInfo.Anonymous.Value(u.Anonymous),
+				tx.ReviewInfo.HasMedia.Value(hasMedia),
+				tx.ReviewInfo.Status.Value(biz.ReviewStatusPending),
+				tx.ReviewInfo.OpReason.Value(""),
+				tx.ReviewInfo.OpRemarks.Value(""),
+				tx.ReviewInfo.OpUser.Value(""),
+				tx.ReviewInfo.Version.Add(1),
+			)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return biz.ErrReviewVersionConflict
+		}
+		// 按修改前的评分移出统计
+		if err := applyReviewStatusStat(ctx, tx, review, review.Status, biz.ReviewStatusPending); err != nil {
+			return err
+		}
+		return addIndexEvent(ctx, tx, review.ReviewID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	r.afterReviewChanged(ctx, review)
+	return u.Version + 1, nil
+}
+
+// DeleteReview 用户删除评论，逻辑删除并移出评分统计和ES索引
+func (r *reviewRepo) DeleteReview(ctx context.Context, review *model.ReviewInfo) error {
+	err := r.data.query.Transaction(func(tx *query.Query) error {
+		updateRes, err := tx.ReviewInfo.WithContext(ctx).
+			Where(
+				tx.ReviewInfo.ReviewID.Eq(review.ReviewID),
+				tx.ReviewInfo.Version.Eq(review.Version),
+				tx.ReviewInfo.Status.Eq(review.Status),
+				tx.ReviewInfo.DeleteAt.IsNull(),
+			).
+			UpdateSimple(
+				tx.ReviewInfo.DeleteAt.Value(time.Now()),
+				tx.ReviewInfo.Version.Add(1),
+			)
+		if err != nil {
+			return err
+		}
+		if updateRes.RowsAffected == 0 {
+			return biz.ErrReviewVersionConflict
+		}
+		if review.Status == biz.ReviewStatusApproved {
+			if err := applyRatingStat(ctx, tx, review.StoreID, review.SpuID, reviewRatingDelta(review, -1)); err != nil {
+				return err
+			}
+		}
+		return addIndexEvent(ctx, tx, review.ReviewID)
+	})
+	if err != nil {
+		return err
+	}
+	r.afterReviewChanged(ctx, review)
+	return nil
+}
+
+// afterReviewChanged 评论内容变更后删除详情缓存并使店铺列表缓存失效，失败只记录日志
+func (r *reviewRepo) afterReviewChanged(ctx context.Context, review *model.ReviewInfo) {
+	if err := r.data.delReviewDetailCache(ctx, review.ReviewID); err != nil {
+		r.log.WithContext(ctx).Warnf("删除评论详情缓存失败: %v", err)
+	}
+	if err := r.data.bumpStoreCacheGen(ctx, review.StoreID); err != nil {
+		r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
 	}
-	return reviews, nil
 }
 
 var g singleflight.Group
 
-// GetSingleflightReviewListByStoreID singleflight放缓存击穿
-func (r *reviewRepo) GetSingleflightReviewListByStoreID(ctx context.Context, storeID int64, offset int32, size int32) ([]*biz.ReviewInfo, error) {
-	key := fmt.Sprintf("review:%d:%d:%d", storeID, offset, size)
+// GetSingleflightReviewListByStoreID 依次查本地缓存、redis、ES，singleflight放缓存击穿，redis不可用时降级直接查ES
+func (r *reviewRepo) GetSingleflightReviewListByStoreID(ctx context.Context, storeID int64, filter *biz.ReviewListFilter, page *biz.ReviewPage) (*biz.ReviewListResult, error) {
+	localKey := fmt.Sprintf("review:%d:l%d:%s:%s:%d", storeID, r.data.localCache.storeGen(storeID), reviewFilterKey(filter), reviewPageKey(page), page.Size)
+	if v, ok := r.data.localCache.get(localKey); ok {
+		return v.(*biz.ReviewListResult), nil
+	}
+
+	cacheable := true
+	gen, err := r.data.getStoreCacheGen(ctx, storeID)
+	if err != nil {
+		r.log.WithContext(ctx).Warnf("获取店铺评论缓存版本失败，降级查询ES: %v", err)
+		cacheable = false
+	}
+	key := fmt.Sprintf("review:%d:g%d:%s:%s:%d", storeID, gen, reviewFilterKey(filter), reviewPageKey(page), page.Size)
+	if !cacheable {
+		key = "nocache:" + key
+	}
 	val, err, _ := g.Do(key, func() (interface{}, error) {
 		// 1. 先从缓存查
-		result, err := r.getDataFromRedis(ctx, key)
-		if err == nil {
-			return result, nil
+		if cacheable {
+			data, err := r.getDataFromRedis(ctx, key)
+			if err == nil {
+				return data, nil
+			}
+			if !errors.Is(err, redis.Nil) {
+				r.log.WithContext(ctx).Warnf("查询评论列表缓存失败，降级查询ES: %v", err)
+				cacheable = false
+			}
+		}
+
+		// 2. 未命中缓存或redis不可用，直接查es
+		result, err := r.GetReviewListByStoreID(ctx, storeID, filter, page)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(result)
+		if err != nil {
+			return nil, errors.New("序列化评论列表失败")
 		}
 
-		// 2. 未命中缓存，直接查es
-		if errors.Is(err, redis.Nil) {
-			result, err := r.GetReviewListByStoreID(ctx, storeID, offset, size)
-			if err != nil {
-				return nil, err
+		// 3. 回写缓存失败不影响本次查询结果，空列表使用较短的过期时间
+		if cacheable {
+			ttl := r.data.cachePolicy.listTTL
+			if len(result.List) == 0 {
+				ttl = r.data.cachePolicy.emptyTTL
 			}
-			data, err := json.Marshal(result)
-			if err != nil {
-				return nil, errors.New("序列化评论列表失败")
+			if err := r.setDataToRedis(ctx, key, data, ttl); err != nil {
+				r.log.WithContext(ctx).Warnf("写入评论列表缓存失败: %v", err)
 			}
-			return data, r.setDataToRedis(ctx, key, data)
 		}
-		// 3. 查不到，说明redis崩了，返回错误
-		return nil, nil
+		return data, nil
 	})
-	rs := val.([]byte)
 	if err != nil {
+		if errors.Is(err, biz.ErrInvalidPageToken) {
+			return nil, err
+		}
 		return nil, errors.New("获取评论列表失败")
 	}
-	reviews := []*biz.ReviewInfo{}
-	err = json.Unmarshal(rs, &reviews)
+	rs, ok := val.([]byte)
+	if !ok {
+		return nil, errors.New("获取评论列表失败")
+	}
+	result := &biz.ReviewListResult{}
+	err = json.Unmarshal(rs, result)
 	if err != nil {
 		return nil, errors.New("解析评论列表失败")
 	}
-	return reviews, nil
+	r.data.localCache.setList(localKey, result)
+	return result, nil
+}
+
+// reviewPageKey 分页参数生成缓存key片段，游标翻页时使用游标的摘要
+func reviewPageKey(page *biz.ReviewPage) string {
+	if page.PageToken == "" {
+		return strconv.Itoa(int(page.Offset))
+	}
+	sum := md5.Sum([]byte(page.PageToken))
+	return "t" + hex.EncodeToString(sum[:8])
+}
+
+// reviewFilterKey 筛选条件生成缓存key片段，相同的筛选条件生成相同的key
+func reviewFilterKey(filter *biz.ReviewListFilter) string {
+	tags := append([]string(nil), filter.Tags...)
+	sort.Strings(tags)
+	var start, end int64
+	if !filter.StartTime.IsZero() {
+		start = filter.StartTime.Unix()
+	}
+	if !filter.EndTime.IsZero() {
+		end = filter.EndTime.Unix()
+	}
+	raw := fmt.Sprintf("%d|%d|%t|%t|%s|%d|%d|%d|%t",
+		filter.MinScore, filter.MaxScore, filter.HasMedia, filter.HasReply,
+		strings.Join(tags, ","), start, end, filter.SortBy, filter.HideDefault)
+	sum := md5.Sum([]byte(raw))
+	return hex.EncodeToString(sum[:8])
+}
+
+// storeCacheGenKey 店铺评论列表缓存版本号，评论变更时自增，使该店铺所有列表缓存同时失效
+func storeCacheGenKey(storeID int64) string {
+	return fmt.Sprintf("review:store:%d:gen", storeID)
+}
+
+// getStoreCacheGen 获取店铺评论列表缓存版本号，未设置时为0
+func (d *Data) getStoreCacheGen(ctx context.Context, storeID int64) (int64, error) {
+	var gen int64
+	err := d.cacheCall(ctx, "get_store_gen", func() (err error) {
+		gen, err = d.cache.Get(ctx, storeCacheGenKey(storeID)).Int64()
+		return err
+	})
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return gen, err
+}
+
+// bumpStoreCacheGen 自增店铺评论列表缓存版本号
+func (d *Data) bumpStoreCacheGen(ctx context.Context, storeIDs ...int64) error {
+	d.localCache.bumpStores(storeIDs...)
+	return d.cacheCall(ctx, "bump_store_gen", func() error {
+		pipe := d.cache.Pipeline()
+		for _, storeID := range storeIDs {
+			pipe.Incr(ctx, storeCacheGenKey(storeID))
+		}
+		_, err := pipe.Exec(ctx)
+		return err
+	})
+}
+
+// bumpStoreCacheGenByReviewID 根据评论ID找到店铺并自增其评论列表缓存版本号
+func (d *Data) bumpStoreCacheGenByReviewID(ctx context.Context, reviewID int64) error {
+	reviewInfo := d.query.ReviewInfo
+	review, err := reviewInfo.WithContext(ctx).
+		Select(reviewInfo.StoreID).
+		Where(reviewInfo.ReviewID.Eq(reviewID)).
+		First()
+	if err != nil {
+		return err
+	}
+	return d.bumpStoreCacheGen(ctx, review.StoreID)
 }
 
 func (r *reviewRepo) getDataFromRedis(ctx context.Context, key string) ([]byte, error) {
-	return r.data.cache.Get(ctx, key).Bytes()
+	var data []byte
+	err := r.data.cacheCall(ctx, "get", func() (err error) {
+		data, err = r.data.cache.Get(ctx, key).Bytes()
+		return err
+	})
+	return data, err
+}
+
+// setDataToRedis 按缓存策略写入redis，过期时间会增加随机抖动，超过大小限制的值不写入
+func (r *reviewRepo) setDataToRedis(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	if !r.data.cachePolicy.cacheable(data) {
+		return nil
+	}
+	return r.data.cacheCall(ctx, "set", func() error {
+		return r.data.cache.Set(ctx, key, data, r.data.cachePolicy.ttl(ttl)).Err()
+	})
+}
+
+// ListReviewsByUserID 根据用户ID按评论ID倒序分页查询评论，并带上商家回复
+func (r *reviewRepo) ListReviewsByUserID(ctx context.Context, param *biz.ListReviewsByUserParam) ([]*biz.ReviewDetail, error) {
+	q := r.data.query
+	do := q.ReviewInfo.WithContext(ctx).Where(q.ReviewInfo.UserID.Eq(param.UserID), q.ReviewInfo.DeleteAt.IsNull())
+	if param.Cursor > 0 {
+		do = do.Where(q.ReviewInfo.ReviewID.Lt(param.Cursor))
+	}
+	if len(param.Statuses) > 0 {
+		do = do.Where(q.ReviewInfo.Status.In(param.Statuses...))
+	}
+	reviews, err := do.Order(q.ReviewInfo.ReviewID.Desc()).Limit(int(param.Size)).Find()
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]*biz.ReviewDetail, len(reviews))
+	replied := make(map[int64]*biz.ReviewDetail)
+	for i, review := range reviews {
+		details[i] = &biz.ReviewDetail{Review: review}
+		if review.HasReply == 1 {
+			replied[review.ReviewID] = details[i]
+		}
+	}
+	if len(details) == 0 {
+		return details, nil
+	}
+
+	// 用户查看自己的评论，展示任意审核状态的追评
+	allIDs := make([]int64, len(reviews))
+	for i, review := range reviews {
+		allIDs[i] = review.ReviewID
+	}
+	appends, err := r.data.getReviewAppends(ctx, allIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, detail := range details {
+		detail.Append = appends[detail.Review.ReviewID]
+	}
+
+	if len(replied) == 0 {
+		return details, nil
+	}
+	reviewIDs := make([]int64, 0, len(replied))
+	for id := range replied {
+		reviewIDs = append(reviewIDs, id)
+	}
+	replies, err := q.ReviewReplyInfo.WithContext(ctx).Where(q.ReviewReplyInfo.ReviewID.In(reviewIDs...), q.ReviewReplyInfo.DeleteAt.IsNull()).Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, reply := range replies {
+		replied[reply.ReviewID].Reply = reply
+	}
+	return details, nil
+}
+
+func reviewDetailKey(reviewID int64) string {
+	return fmt.Sprintf("review:detail:%d", reviewID)
+}
+
+// delReviewDetailCache 评论、回复、追评、申诉变更后删除评论详情缓存
+func (d *Data) delReviewDetailCache(ctx context.Context, reviewIDs ...int64) error {
+	keys := make([]string, len(reviewIDs))
+	for i, id := range reviewIDs {
+		keys[i] = reviewDetailKey(id)
+	}
+	d.localCache.remove(keys...)
+	return d.cacheCall(ctx, "del", func() error {
+		return d.cache.Del(ctx, keys...).Err()
+	})
+}
+
+// GetReviewDetail 根据评论ID获取评论详情，优先读缓存
+func (r *reviewRepo) GetReviewDetail(ctx context.Context, reviewID int64) (*biz.ReviewDetail, error) {
+	key := reviewDetailKey(reviewID)
+	if v, ok := r.data.localCache.get(key); ok {
+		return v.(*biz.ReviewDetail), nil
+	}
+	val, err, _ := g.Do(key, func() (interface{}, error) {
+		// 1. 先从缓存查，redis异常时降级查库
+		data, err := r.getDataFromRedis(ctx, key)
+		if err == nil {
+			if bytes.Equal(data, emptyCacheValue) {
+				return nil, gorm.ErrRecordNotFound
+			}
+			detail := &biz.ReviewDetail{}
+			if err := json.Unmarshal(data, detail); err == nil {
+				return detail, nil
+			}
+		} else if !errors.Is(err, redis.Nil) {
+			r.log.WithContext(ctx).Warnf("查询评论详情缓存失败: %v", err)
+		}
+
+		// 2. 未命中缓存，查库并回写缓存
+		details, err := r.getReviewDetailsFromDB(ctx, []int64{reviewID})
+		if err != nil {
+			return nil, err
+		}
+		if len(details) == 0 {
+			r.setReviewDetailCache(ctx, []int64{reviewID}, nil)
+			return nil, gorm.ErrRecordNotFound
+		}
+		r.setReviewDetailCache(ctx, nil, details)
+		return details[0], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	detail := val.(*biz.ReviewDetail)
+	r.data.localCache.setDetail(key, detail)
+	return detail, nil
+}
+
+// BatchGetReviewDetails 批量获取评论详情，按reviewIDs的顺序返回
+func (r *reviewRepo) BatchGetReviewDetails(ctx context.Context, reviewIDs []int64) ([]*biz.ReviewDetail, error) {
+	// 1. 先查本地缓存
+	found := make(map[int64]*biz.ReviewDetail, len(reviewIDs))
+	remoteIDs := make([]int64, 0, len(reviewIDs))
+	keys := make([]string, 0, len(reviewIDs))
+	for _, id := range reviewIDs {
+		key := reviewDetailKey(id)
+		if v, ok := r.data.localCache.get(key); ok {
+			found[id] = v.(*biz.ReviewDetail)
+			continue
+		}
+		remoteIDs = append(remoteIDs, id)
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return orderReviewDetails(reviewIDs, found), nil
+	}
+
+	// 2. 批量查redis，redis异常时全部查库
+	var vals []interface{}
+	err := r.data.cacheCall(ctx, "mget", func() (err error) {
+		vals, err = r.data.cache.MGet(ctx, keys...).Result()
+		return err
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Warnf("批量查询评论详情缓存失败: %v", err)
+		vals = make([]interface{}, len(keys))
+	}
+	missed := make([]int64, 0, len(remoteIDs))
+	for i, v := range vals {
+		s, ok := v.(string)
+		if !ok {
+			missed = append(missed, remoteIDs[i])
+			continue
+		}
+		if s == string(emptyCacheValue) {
+			continue
+		}
+		detail := &biz.ReviewDetail{}
+		if err := json.Unmarshal([]byte(s), detail); err != nil {
+			missed = append(missed, remoteIDs[i])
+			continue
+		}
+		found[remoteIDs[i]] = detail
+		r.data.localCache.setDetail(keys[i], detail)
+	}
+
+	// 3. 未命中的查库并回写缓存
+	if len(missed) > 0 {
+		details, err := r.getReviewDetailsFromDB(ctx, missed)
+		if err != nil {
+			return nil, err
+		}
+		for _, detail := range details {
+			found[detail.Review.ReviewID] = detail
+			r.data.localCache.setDetail(reviewDetailKey(detail.Review.ReviewID), detail)
+		}
+		notFound := make([]int64, 0, len(missed)-len(details))
+		for _, id := range missed {
+			if _, ok := found[id]; !ok {
+				notFound = append(notFound, id)
+			}
+		}
+		r.setReviewDetailCache(ctx, notFound, details)
+	}
+	return orderReviewDetails(reviewIDs, found), nil
 }
 
-func (r *reviewRepo) setDataToRedis(ctx context.Context, key string, data []byte) error {
-	return r.data.cache.Set(ctx, key, data, 1*time.Minute).Err()
+// orderReviewDetails 按reviewIDs的顺序返回查到的评论详情
+func orderReviewDetails(reviewIDs []int64, found map[int64]*biz.ReviewDetail) []*biz.ReviewDetail {
+	result := make([]*biz.ReviewDetail, 0, len(found))
+	for _, id := range reviewIDs {
+		if detail, ok := found[id]; ok {
+			result = append(result, detail)
+		}
+	}
+	return result
+}
+
+// getReviewDetailsFromDB 从数据库查询评论及其回复、审核通过的追评、最近一次申诉
+func (r *reviewRepo) getReviewDetailsFromDB(ctx context.Context, reviewIDs []int64) ([]*biz.ReviewDetail, error) {
+	q := r.data.query
+	reviews, err := q.ReviewInfo.WithContext(ctx).Where(q.ReviewInfo.ReviewID.In(reviewIDs...), q.ReviewInfo.DeleteAt.IsNull()).Find()
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return nil, nil
+	}
+	replies, err := q.ReviewReplyInfo.WithContext(ctx).Where(q.ReviewReplyInfo.ReviewID.In(reviewIDs...), q.ReviewReplyInfo.DeleteAt.IsNull()).Find()
+	if err != nil {
+		return nil, err
+	}
+	appeals, err := q.ReviewAppealInfo.WithContext(ctx).
+		Where(q.ReviewAppealInfo.ReviewID.In(reviewIDs...)).
+		Order(q.ReviewAppealInfo.ID.Desc()).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+
+	details := make(map[int64]*biz.ReviewDetail, len(reviews))
+	result := make([]*biz.ReviewDetail, len(reviews))
+	for i, review := range reviews {
+		result[i] = &biz.ReviewDetail{Review: review}
+		details[review.ReviewID] = result[i]
+	}
+	for _, reply := range replies {
+		if detail, ok := details[reply.ReviewID]; ok {
+			detail.Reply = reply
+		}
+	}
+	for _, appeal := range appeals {
+		// 按id倒序，只保留最近一次申诉
+		if detail, ok := details[appeal.ReviewID]; ok && detail.Appeal == nil {
+			detail.Appeal = appeal
+		}
+	}
+	// 详情对外公开，只展示审核通过的追评
+	appends, err := r.data.getReviewAppends(ctx, reviewIDs, true)
+	if err != nil {
+		return nil, err
+	}
+	for id, reviewAppend := range appends {
+		if detail, ok := details[id]; ok {
+			detail.Append = reviewAppend
+		}
+	}
+	return result, nil
+}
+
+// setReviewDetailCache 回写评论详情缓存，不存在的评论写入空值占位，失败只记录日志
+func (r *reviewRepo) setReviewDetailCache(ctx context.Context, notFound []int64, details []*biz.ReviewDetail) {
+	policy := r.data.cachePolicy
+	pipe := r.data.cache.Pipeline()
+	for _, id := range notFound {
+		pipe.Set(ctx, reviewDetailKey(id), emptyCacheValue, policy.ttl(policy.emptyTTL))
+	}
+	for _, detail := range details {
+		data, err := json.Marshal(detail)
+		if err != nil {
+			r.log.WithContext(ctx).Warnf("序列化评论详情失败: %v", err)
+			continue
+		}
+		if !policy.cacheable(data) {
+			continue
+		}
+		pipe.Set(ctx, reviewDetailKey(detail.Review.ReviewID), data, policy.ttl(policy.detailTTL))
+	}
+	if pipe.Len() == 0 {
+		return
+	}
+	err := r.data.cacheCall(ctx, "set", func() error {
+		_, err := pipe.Exec(ctx)
+		return err
+	})
+	if err != nil {
+		r.log.WithContext(ctx).Warnf("回写评论详情缓存失败: %v", err)
+	}
 }
@@ -0,0 +1,217 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+	"review-service/internal/data/query"
+	"strconv"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/refresh"
+	"github.com/elastic/go-elasticsearch/v9/typedapi/types/enums/versiontype"
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+)
+
+// ReviewIndexAlias 评论ES索引别名，实际索引为带版本号的review_v{n}，由cmd/esindex维护
+const ReviewIndexAlias = "review"
+
+type reviewIndexRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+// NewReviewIndexRepo .
+func NewReviewIndexRepo(data *Data, logger log.Logger) biz.ReviewIndexRepo {
+	return &reviewIndexRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+// addIndexEvent 在事务中写入评论ES同步事件，需与评论变更使用同一个tx
+func addIndexEvent(ctx context.Context, tx *query.Query, reviewIDs ...int64) error {
+	events := make([]*model.ReviewEsOutbox, 0, len(reviewIDs))
+	for _, reviewID := range reviewIDs {
+		events = append(events, &model.ReviewEsOutbox{
+			ReviewID: reviewID,
+			Status:   biz.IndexEventStatusPending,
+		})
+	}
+	return tx.ReviewEsOutbox.WithContext(ctx).Create(events...)
+}
+
+// ListPendingIndexEvents 获取到期待同步的事件
+func (r *reviewIndexRepo) ListPendingIndexEvents(ctx context.Context, limit int) ([]*biz.ReviewIndexEvent, error) {
+	outbox := r.data.query.ReviewEsOutbox
+	rows, err := outbox.WithContext(ctx).
+		Where(outbox.Status.Eq(biz.IndexEventStatusPending), outbox.NextRetryAt.Lte(time.Now())).
+		Order(outbox.ID).
+		Limit(limit).
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*biz.ReviewIndexEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, &biz.ReviewIndexEvent{
+			ID:         row.ID,
+			ReviewID:   row.ReviewID,
+			RetryCount: row.RetryCount,
+		})
+	}
+	return events, nil
+}
+
+// SyncReviewIndex 以MySQL中的评论为准同步ES文档，评论不存在或已删除时删除文档。
+// 事件ID自增且与评论变更同事务写入，读到的快照不早于该事件，以事件ID作为external_gte版本号写入，
+// 其他副本已写入更新的版本时ES返回版本冲突，视为成功
+func (r *reviewIndexRepo) SyncReviewIndex(ctx context.Context, reviewID int64, version int64) error {
+	reviewInfo := r.data.query.ReviewInfo
+	review, err := reviewInfo.WithContext(ctx).Where(reviewInfo.ReviewID.Eq(reviewID)).First()
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	id := strconv.FormatInt(reviewID, 10)
+	esVersion := strconv.FormatInt(version, 10)
+	if review == nil || review.DeleteAt != nil {
+		_, err := r.data.esClient.Delete(ReviewIndexAlias, id).
+			Version(esVersion).
+			VersionType(versiontype.Externalgte).
+			Refresh(refresh.Waitfor).
+			Do(ctx)
+		if isEsStatus(err, http.StatusNotFound) || isEsStatus(err, http.StatusConflict) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		r.bumpStoreCacheGen(ctx, review)
+		return nil
+	}
+	appends, err := r.data.getReviewAppends(ctx, []int64{reviewID}, true)
+	if err != nil {
+		return err
+	}
+	_, err = r.data.esClient.Index(ReviewIndexAlias).
+		Id(id).
+		Version(esVersion).
+		VersionType(versiontype.Externalgte).
+		Refresh(refresh.Waitfor).
+		Document(ToReviewDoc(review, appends[reviewID])).
+		Do(ctx)
+	if isEsStatus(err, http.StatusConflict) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	r.bumpStoreCacheGen(ctx, review)
+	return nil
+}
+
+// bumpStoreCacheGen 列表读的是ES，写入ES并等待刷新可见后再使一次店铺列表缓存失效，
+// 避免刷新间隔内的读请求把写入前的列表缓存到新版本下
+func (r *reviewIndexRepo) bumpStoreCacheGen(ctx context.Context, review *model.ReviewInfo) {
+	if review == nil {
+		return
+	}
+	if err := r.data.bumpStoreCacheGen(ctx, review.StoreID); err != nil {
+		r.log.WithContext(ctx).Warnf("更新店铺评论缓存版本失败: %v", err)
+	}
+}
+
+// last_error列长度
+const maxIndexErrorLen = 1024
+
+// truncateRunes 按字符截断，避免超过列长度写入失败
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+// isEsStatus 判断是否为指定状态码的ES错误
+func isEsStatus(err error, status int) bool {
+	var esErr *types.ElasticsearchError
+	return errors.As(err, &esErr) && esErr.Status == status
+}
+
+// MarkIndexEventsDone 标记事件同步成功
+func (r *reviewIndexRepo) MarkIndexEventsDone(ctx context.Context, ids ...int64) error {
+	outbox := r.data.query.ReviewEsOutbox
+	_, err := outbox.WithContext(ctx).
+		Where(outbox.ID.In(ids...)).
+		UpdateSimple(outbox.Status.Value(biz.IndexEventStatusDone))
+	return err
+}
+
+// MarkIndexEventFailed 记录同步失败，dead为true时不再重试
+func (r *reviewIndexRepo) MarkIndexEventFailed(ctx context.Context, event *biz.ReviewIndexEvent, nextRetryAt time.Time, dead bool, reason string) error {
+	status := biz.IndexEventStatusPending
+	if dead {
+		status = biz.IndexEventStatusDead
+	}
+	outbox := r.data.query.ReviewEsOutbox
+	_, err := outbox.WithContext(ctx).
+		Where(outbox.ID.Eq(event.ID)).
+		UpdateSimple(
+			outbox.Status.Value(status),
+			outbox.RetryCount.Value(event.RetryCount),
+			outbox.NextRetryAt.Value(nextRetryAt),
+			outbox.LastError.Value(truncateRunes(reason, maxIndexErrorLen)),
+		)
+	return err
+}
+
+// ToReviewDoc 将评论及其审核通过的追评转换为ES文档，reviewAppend可为nil
+func ToReviewDoc(review *model.ReviewInfo, reviewAppend *model.ReviewAppendInfo) *biz.ReviewInfo {
+	doc := &biz.ReviewInfo{
+		ID:             review.ID,
+		CreateBy:       review.CreateBy,
+		UpdateBy:       review.UpdateBy,
+		CreateAt:       biz.Mytime(review.CreateAt),
+		UpdateAt:       biz.Mytime(review.UpdateAt),
+		Version:        review.Version,
+		ReviewID:       review.ReviewID,
+		Content:        review.Content,
+		Score:          review.Score,
+		ServiceScore:   review.ServiceScore,
+		ExpressScore:   review.ExpressScore,
+		HasMedia:       review.HasMedia,
+		OrderID:        review.OrderID,
+		SkuID:          review.SkuID,
+		SpuID:          review.SpuID,
+		StoreID:        review.StoreID,
+		UserID:         review.UserID,
+		Anonymous:      review.Anonymous,
+		Tags:           review.Tags,
+		PicInfo:        review.PicInfo,
+		VideoInfo:      review.VideoInfo,
+		Status:         review.Status,
+		IsDefault:      review.IsDefault,
+		HasReply:       review.HasReply,
+		OpReason:       review.OpReason,
+		OpRemarks:      review.OpRemarks,
+		OpUser:         review.OpUser,
+		GoodsSnapshoot: review.GoodsSnapshoot,
+		ExtJSON:        review.ExtJSON,
+		CtrlJSON:       review.CtrlJSON,
+	}
+	if reviewAppend != nil {
+		doc.Append = &biz.ReviewAppendInfo{
+			AppendID:  reviewAppend.AppendID,
+			Content:   reviewAppend.Content,
+			PicInfo:   reviewAppend.PicInfo,
+			VideoInfo: reviewAppend.VideoInfo,
+			CreateAt:  biz.Mytime(reviewAppend.CreateAt),
+		}
+	}
+	return doc
+}
@@ -0,0 +1,32 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+
+	"review-service/internal/biz"
+	"review-service/internal/data/model"
+)
+
+// TestStoreReviewHasMediaFilter 带图评论写入ES的文档能被“只看有图”筛选命中
+func TestStoreReviewHasMediaFilter(t *testing.T) {
+	review := &model.ReviewInfo{ReviewID: 1, StoreID: 100, Status: biz.ReviewStatusApproved, PicInfo: `["a.jpg"]`}
+	review.HasMedia = biz.ReviewHasMedia(review.PicInfo, review.VideoInfo)
+	doc := ToReviewDoc(review, nil)
+
+	q := buildStoreReviewQuery(100, &biz.ReviewListFilter{HasMedia: true})
+	var matched bool
+	for _, f := range q.Bool.Filter {
+		term, ok := f.Term["has_media"]
+		if !ok {
+			continue
+		}
+		matched = true
+		if fmt.Sprint(term.Value) != fmt.Sprint(doc.HasMedia) {
+			t.Fatalf("has_media filter %v does not match document %d", term.Value, doc.HasMedia)
+		}
+	}
+	if !matched {
+		t.Fatalf("has_media filter missing: %+v", q.Bool.Filter)
+	}
+}
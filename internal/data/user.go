@@ -0,0 +1,70 @@
+package data
+
+import (
+	"context"
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+
+	userv1 "review-service/api/user/v1"
+
+	"github.com/go-kratos/kratos/contrib/registry/consul/v2"
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/recovery"
+	"github.com/go-kratos/kratos/v2/transport/grpc"
+)
+
+const defaultUserServiceEndpoint = "discovery:///user-service"
+
+// NewUserServiceClient 通过注册中心发现用户服务并建立gRPC连接
+func NewUserServiceClient(c *conf.Data, r *consul.Registry) (userv1.UserClient, func(), error) {
+	endpoint := c.GetUserService().GetEndpoint()
+	if endpoint == "" {
+		endpoint = defaultUserServiceEndpoint
+	}
+	opts := []grpc.ClientOption{
+		grpc.WithEndpoint(endpoint),
+		grpc.WithDiscovery(r),
+		grpc.WithMiddleware(recovery.Recovery()),
+	}
+	if timeout := c.GetUserService().GetTimeout(); timeout != nil {
+		opts = append(opts, grpc.WithTimeout(timeout.AsDuration()))
+	}
+	conn, err := grpc.DialInsecure(context.Background(), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = conn.Close()
+	}
+	return userv1.NewUserClient(conn), cleanup, nil
+}
+
+type userClient struct {
+	client userv1.UserClient
+	log    *log.Helper
+}
+
+// NewUserClient .
+func NewUserClient(client userv1.UserClient, logger log.Logger) biz.UserClient {
+	return &userClient{
+		client: client,
+		log:    log.NewHelper(logger),
+	}
+}
+
+// BatchGetUsers 调用用户服务批量查询用户资料
+func (c *userClient) BatchGetUsers(ctx context.Context, userIDs []int64) (map[int64]*biz.UserProfile, error) {
+	reply, err := c.client.BatchGetUsers(ctx, &userv1.BatchGetUsersRequest{UserIds: userIDs})
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[int64]*biz.UserProfile, len(reply.GetUsers()))
+	for _, user := range reply.GetUsers() {
+		users[user.UserId] = &biz.UserProfile{
+			UserID:   user.UserId,
+			Nickname: user.Nickname,
+			Avatar:   user.Avatar,
+		}
+	}
+	return users, nil
+}
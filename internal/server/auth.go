@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"review-service/internal/conf"
+	"review-service/internal/service"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/transport"
+	jwtv5 "github.com/golang-jwt/jwt/v5"
+)
+
+// viewerAuth 校验Authorization中的JWT并注入调用方身份，未携带token的请求按未登录放行，
+// token无效时拒绝请求，调用方身份只能来自签名有效的token
+func viewerAuth(c *conf.Server_Auth) middleware.Middleware {
+	secret := []byte(c.GetJwtSecret())
+	verify := jwt.Server(
+		func(*jwtv5.Token) (interface{}, error) {
+			if len(secret) == 0 {
+				return nil, errors.New("jwt secret not configured")
+			}
+			return secret, nil
+		},
+		jwt.WithSigningMethod(jwtv5.SigningMethodHS256),
+		jwt.WithClaims(func() jwtv5.Claims { return &service.ViewerClaims{} }),
+	)
+	return func(handler middleware.Handler) middleware.Handler {
+		authed := verify(handler)
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok && tr.RequestHeader().Get("Authorization") != "" {
+				return authed(ctx, req)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
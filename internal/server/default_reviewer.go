@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+var _ transport.Server = (*DefaultReviewer)(nil)
+
+// DefaultReviewer 默认评价后台任务，定时为超时未评价的订单商品生成默认好评，多副本通过分布式锁只有一个执行
+type DefaultReviewer struct {
+	uc        *biz.DefaultReviewUsecase
+	interval  time.Duration
+	batchSize int
+	lockTTL   time.Duration
+	stop      chan struct{}
+	stopOnce  sync.Once
+	log       *log.Helper
+}
+
+// NewDefaultReviewer new a default reviewer server.
+func NewDefaultReviewer(c *conf.Server, uc *biz.DefaultReviewUsecase, logger log.Logger) *DefaultReviewer {
+	dr := &DefaultReviewer{
+		uc:        uc,
+		interval:  10 * time.Minute,
+		batchSize: 100,
+		lockTTL:   5 * time.Minute,
+		stop:      make(chan struct{}),
+		log:       log.NewHelper(logger),
+	}
+	if c.DefaultReviewer.GetInterval() != nil {
+		dr.interval = c.DefaultReviewer.GetInterval().AsDuration()
+	}
+	if c.DefaultReviewer.GetBatchSize() > 0 {
+		dr.batchSize = int(c.DefaultReviewer.GetBatchSize())
+	}
+	if c.DefaultReviewer.GetLockTtl() != nil {
+		dr.lockTTL = c.DefaultReviewer.GetLockTtl().AsDuration()
+	}
+	return dr
+}
+
+// Start 启动默认评价任务，阻塞直到Stop
+func (s *DefaultReviewer) Start(ctx context.Context) error {
+	s.log.Infof("[DefaultReviewer] server starting, interval: %s", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// 一批处理满说明还有积压，继续处理直到清空
+			for {
+				n, err := s.uc.RunOnce(ctx, s.batchSize, s.lockTTL)
+				if err != nil {
+					s.log.Errorf("生成默认评价失败: %v", err)
+					break
+				}
+				if n < s.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Stop 停止默认评价任务
+func (s *DefaultReviewer) Stop(ctx context.Context) error {
+	s.log.Info("[DefaultReviewer] server stopping")
+	// kratos在Start失败后仍会调用Stop，可能被调用多次
+	s.stopOnce.Do(func() { close(s.stop) })
+	return nil
+}
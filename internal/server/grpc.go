@@ -12,10 +12,11 @@ import (
 )
 
 // NewGRPCServer new a gRPC server.
-func NewGRPCServer(c *conf.Server, review *service.ReviewService, logger log.Logger) *grpc.Server {
+func NewGRPCServer(c *conf.Server, review *service.ReviewService, appeal *service.AppealService, logger log.Logger) *grpc.Server {
 	var opts = []grpc.ServerOption{
 		grpc.Middleware(
 			recovery.Recovery(),
+			viewerAuth(c.Auth),
 			validate.Validator(),
 		),
 	}
@@ -30,5 +31,6 @@ func NewGRPCServer(c *conf.Server, review *service.ReviewService, logger log.Log
 	}
 	srv := grpc.NewServer(opts...)
 	v1.RegisterReviewServer(srv, review)
+	v1.RegisterAppealServer(srv, appeal)
 	return srv
 }
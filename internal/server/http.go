@@ -12,10 +12,11 @@ import (
 )
 
 // NewHTTPServer new an HTTP server.
-func NewHTTPServer(c *conf.Server, review *service.ReviewService, logger log.Logger) *http.Server {
+func NewHTTPServer(c *conf.Server, review *service.ReviewService, appeal *service.AppealService, logger log.Logger) *http.Server {
 	var opts = []http.ServerOption{
 		http.Middleware(
 			recovery.Recovery(),
+			viewerAuth(c.Auth),
 			validate.Validator(),
 		),
 	}
@@ -32,5 +33,6 @@ func NewHTTPServer(c *conf.Server, review *service.ReviewService, logger log.Log
 	opts = append(opts, http.ErrorEncoder(ErrorEncoder))
 	srv := http.NewServer(opts...)
 	v1.RegisterReviewHTTPServer(srv, review)
+	v1.RegisterAppealHTTPServer(srv, appeal)
 	return srv
 }
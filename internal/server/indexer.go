@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"review-service/internal/biz"
+	"review-service/internal/conf"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+var _ transport.Server = (*ReviewIndexer)(nil)
+
+// ReviewIndexer 评论ES同步后台任务，定时消费同步事件
+type ReviewIndexer struct {
+	uc         *biz.ReviewIndexUsecase
+	interval   time.Duration
+	batchSize  int
+	maxRetries int32
+	stop       chan struct{}
+	stopOnce   sync.Once
+	log        *log.Helper
+}
+
+// NewReviewIndexer new a review indexer server.
+func NewReviewIndexer(c *conf.Server, uc *biz.ReviewIndexUsecase, logger log.Logger) *ReviewIndexer {
+	idx := &ReviewIndexer{
+		uc:         uc,
+		interval:   time.Second,
+		batchSize:  100,
+		maxRetries: 10,
+		stop:       make(chan struct{}),
+		log:        log.NewHelper(logger),
+	}
+	if c.Indexer.GetInterval() != nil {
+		idx.interval = c.Indexer.GetInterval().AsDuration()
+	}
+	if c.Indexer.GetBatchSize() > 0 {
+		idx.batchSize = int(c.Indexer.GetBatchSize())
+	}
+	if c.Indexer.GetMaxRetries() > 0 {
+		idx.maxRetries = c.Indexer.GetMaxRetries()
+	}
+	return idx
+}
+
+// Start 启动同步任务，阻塞直到Stop
+func (s *ReviewIndexer) Start(ctx context.Context) error {
+	s.log.Infof("[Indexer] server starting, interval: %s", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			// 一批处理满说明还有积压，继续处理直到清空
+			for {
+				n, err := s.uc.SyncPending(ctx, s.batchSize, s.maxRetries)
+				if err != nil {
+					s.log.Errorf("同步评论到ES失败: %v", err)
+					break
+				}
+				if n < s.batchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// Stop 停止同步任务
+func (s *ReviewIndexer) Stop(ctx context.Context) error {
+	s.log.Info("[Indexer] server stopping")
+	// kratos在Start失败后仍会调用Stop，可能被调用多次
+	s.stopOnce.Do(func() { close(s.stop) })
+	return nil
+}
@@ -9,7 +9,7 @@ import (
 )
 
 // ProviderSet is server providers.
-var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer, NewConsulRegistrar)
+var ProviderSet = wire.NewSet(NewGRPCServer, NewHTTPServer, NewReviewIndexer, NewDefaultReviewer, NewConsulRegistrar)
 
 // 服务注册
 func NewConsulRegistrar(rc *conf.Registry) *consul.Registry {
@@ -8,6 +8,7 @@ import (
 )
 
 type AppealService struct {
+	pb.UnimplementedAppealServer
 	uc *biz.AppealUsecase
 }
 
@@ -18,14 +19,28 @@ func NewAppealService(uc *biz.AppealUsecase) *AppealService {
 // CreateAppeal 创建申诉
 func (s *AppealService) CreateAppeal(ctx context.Context, req *pb.CreateAppealRequest) (*pb.CreateAppealResponse, error) {
 	appealID, err := s.uc.SaveAppeal(ctx, &biz.Appeal{
-		ReviewID: req.ReviewId,
-		StoreID:  req.StoreId,
-		Content:  req.Content,
-		PicInfo:  req.PicInfo,
+		ReviewID:  req.ReviewId,
+		StoreID:   req.StoreId,
+		Content:   req.Content,
+		PicInfo:   req.PicInfo,
 		VideoInfo: req.VideoInfo,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &pb.CreateAppealResponse{AppealId: appealID}, nil
-}
\ No newline at end of file
+}
+
+// AuditAppeal 运营审核申诉
+func (s *AppealService) AuditAppeal(ctx context.Context, req *pb.AuditAppealRequest) (*pb.AuditAppealResponse, error) {
+	err := s.uc.AuditAppeal(ctx, &biz.AuditAppeal{
+		AppealID:  req.AppealId,
+		Status:    req.Status,
+		OpUser:    req.OpUser,
+		OpRemarks: req.OpRemarks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuditAppealResponse{AppealId: req.AppealId, Status: req.Status}, nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	pb "review-service/api/review/v1"
+	"review-service/internal/biz"
+
+	"github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	jwtv5 "github.com/golang-jwt/jwt/v5"
+)
+
+// ViewerClaims 调用方身份的JWT声明，sub为用户ID，由server的认证中间件校验签名后注入
+type ViewerClaims struct {
+	jwtv5.RegisteredClaims
+	Role string `json:"role"`
+}
+
+var viewerRoles = map[string]biz.ViewerRole{
+	"user":     biz.ViewerRoleUser,
+	"merchant": biz.ViewerRoleMerchant,
+	"operator": biz.ViewerRoleOperator,
+}
+
+// viewerFromContext 从校验过的JWT中解析调用方身份，未携带token或角色无法识别时按未登录处理
+func viewerFromContext(ctx context.Context) *biz.Viewer {
+	viewer := &biz.Viewer{Role: biz.ViewerRoleGuest}
+	claims, ok := jwt.FromContext(ctx)
+	if !ok {
+		return viewer
+	}
+	vc, ok := claims.(*ViewerClaims)
+	if !ok {
+		return viewer
+	}
+	if role, ok := viewerRoles[vc.Role]; ok {
+		viewer.Role = role
+	}
+	viewer.UserID, _ = strconv.ParseInt(vc.Subject, 10, 64)
+	return viewer
+}
+
+// fillAuthors 填充评论作者的展示信息，匿名评论按调用方角色脱敏
+func (s *ReviewService) fillAuthors(ctx context.Context, reviews []*pb.ReviewInfo) {
+	if len(reviews) == 0 {
+		return
+	}
+	refs := make([]*biz.AuthorRef, len(reviews))
+	for i, review := range reviews {
+		refs[i] = &biz.AuthorRef{ReviewID: review.ReviewId, UserID: review.UserId, Anonymous: review.Anonymous == 1}
+	}
+	authors := s.author.ResolveAuthors(ctx, viewerFromContext(ctx), refs)
+	for i, author := range authors {
+		reviews[i].UserId = author.UserID
+		reviews[i].UserToken = author.Token
+		reviews[i].Nickname = author.Nickname
+		reviews[i].Avatar = author.Avatar
+		if author.Masked {
+			// 订单号可关联同一订单的其他评论，也能通过订单服务查到买家
+			reviews[i].OrderId = 0
+		}
+	}
+}
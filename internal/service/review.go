@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	pb "review-service/api/review/v1"
 	"review-service/internal/biz"
@@ -10,12 +11,14 @@ import (
 
 type ReviewService struct {
 	pb.UnimplementedReviewServer
-	uc *biz.ReviewUsecase
+	uc     *biz.ReviewUsecase
+	author *biz.ReviewAuthorUsecase
 }
 
-func NewReviewService(uc *biz.ReviewUsecase) *ReviewService {
+func NewReviewService(uc *biz.ReviewUsecase, author *biz.ReviewAuthorUsecase) *ReviewService {
 	return &ReviewService{
-		uc: uc,
+		uc:     uc,
+		author: author,
 	}
 }
 
@@ -25,6 +28,8 @@ func (s *ReviewService) CreateReview(ctx context.Context, req *pb.CreateReviewRe
 		UserID:       req.UserId,
 		OrderID:      req.OrderId,
 		StoreID:      req.StoreId,
+		SkuID:        req.SkuId,
+		SpuID:        req.SpuId,
 		PicInfo:      req.PicInfo,
 		VideoInfo:    req.VideoInfo,
 		Content:      req.Content,
@@ -40,6 +45,60 @@ func (s *ReviewService) CreateReview(ctx context.Context, req *pb.CreateReviewRe
 	return &pb.CreateReviewResponse{ReviewId: reviewID}, nil
 }
 
+// 用户批量评论同一订单的多个商品
+func (s *ReviewService) BatchCreateReviews(ctx context.Context, req *pb.BatchCreateReviewsRequest) (*pb.BatchCreateReviewsResponse, error) {
+	reviews := make([]*model.ReviewInfo, len(req.Items))
+	for i, item := range req.Items {
+		reviews[i] = &model.ReviewInfo{
+			UserID:       req.UserId,
+			OrderID:      req.OrderId,
+			StoreID:      req.StoreId,
+			SkuID:        item.SkuId,
+			SpuID:        item.SpuId,
+			PicInfo:      item.PicInfo,
+			VideoInfo:    item.VideoInfo,
+			Content:      item.Content,
+			Score:        item.Score,
+			ServiceScore: item.ServiceScore,
+			ExpressScore: item.ExpressScore,
+			Anonymous:    item.Anonymous,
+		}
+	}
+	reviewIDs, err := s.uc.BatchSaveReviews(ctx, reviews)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BatchCreateReviewsResponse{ReviewIds: reviewIDs}, nil
+}
+
+// 用户修改评论
+func (s *ReviewService) UpdateReview(ctx context.Context, req *pb.UpdateReviewRequest) (*pb.UpdateReviewResponse, error) {
+	version, err := s.uc.UpdateReview(ctx, &biz.UpdateReview{
+		ReviewID:     req.ReviewId,
+		UserID:       req.UserId,
+		Version:      req.Version,
+		Content:      req.Content,
+		PicInfo:      req.PicInfo,
+		VideoInfo:    req.VideoInfo,
+		Score:        req.Score,
+		ServiceScore: req.ServiceScore,
+		ExpressScore: req.ExpressScore,
+		Anonymous:    req.Anonymous,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateReviewResponse{ReviewId: req.ReviewId, Version: version}, nil
+}
+
+// 用户删除评论
+func (s *ReviewService) DeleteReview(ctx context.Context, req *pb.DeleteReviewRequest) (*pb.DeleteReviewResponse, error) {
+	if err := s.uc.DeleteReview(ctx, req.ReviewId, req.UserId); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteReviewResponse{}, nil
+}
+
 // 商家评论回复
 func (s *ReviewService) ReplyReview(ctx context.Context, req *pb.ReviewReplyRequest) (*pb.ReviewReplyResponse, error) {
 	replyID, err := s.uc.ReplyReview(ctx, &biz.ReviewReply{
@@ -55,25 +114,371 @@ func (s *ReviewService) ReplyReview(ctx context.Context, req *pb.ReviewReplyRequ
 	return &pb.ReviewReplyResponse{ReplyId: replyID}, nil
 }
 
+// 商家修改回复
+func (s *ReviewService) UpdateReply(ctx context.Context, req *pb.UpdateReplyRequest) (*pb.UpdateReplyResponse, error) {
+	err := s.uc.UpdateReply(ctx, &biz.ReviewReply{
+		ReplyID:   req.ReplyId,
+		StoreID:   req.StoreId,
+		PicInfo:   req.PicInfo,
+		VideoInfo: req.VideoInfo,
+		Content:   req.Content,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateReplyResponse{ReplyId: req.ReplyId}, nil
+}
+
+// 商家删除回复
+func (s *ReviewService) DeleteReply(ctx context.Context, req *pb.DeleteReplyRequest) (*pb.DeleteReplyResponse, error) {
+	if err := s.uc.DeleteReply(ctx, req.ReplyId, req.StoreId); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteReplyResponse{}, nil
+}
+
+// 商家后台查询店铺的回复列表
+func (s *ReviewService) ListRepliesByStore(ctx context.Context, req *pb.ListRepliesByStoreRequest) (*pb.ListRepliesByStoreResponse, error) {
+	details, nextCursor, err := s.uc.ListRepliesByStore(ctx, &biz.ListRepliesByStoreParam{
+		StoreID: req.StoreId,
+		Cursor:  req.Cursor,
+		Size:    req.Size,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pbReviews := make([]*pb.ReviewInfo, len(details))
+	for i, detail := range details {
+		pbReviews[i] = toPbReviewDetail(detail)
+	}
+	s.fillAuthors(ctx, pbReviews)
+	return &pb.ListRepliesByStoreResponse{List: pbReviews, NextCursor: nextCursor, HasMore: nextCursor > 0}, nil
+}
+
+// 用户追评
+func (s *ReviewService) AppendReview(ctx context.Context, req *pb.AppendReviewRequest) (*pb.AppendReviewResponse, error) {
+	appendID, err := s.uc.AppendReview(ctx, &biz.ReviewAppend{
+		ReviewID:  req.ReviewId,
+		UserID:    req.UserId,
+		Content:   req.Content,
+		PicInfo:   req.PicInfo,
+		VideoInfo: req.VideoInfo,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AppendReviewResponse{AppendId: appendID}, nil
+}
+
+// 运营审核追评
+func (s *ReviewService) AuditReviewAppend(ctx context.Context, req *pb.AuditReviewAppendRequest) (*pb.AuditReviewAppendResponse, error) {
+	err := s.uc.AuditReviewAppend(ctx, &biz.AuditReviewAppend{
+		AppendID:  req.AppendId,
+		Status:    req.Status,
+		OpReason:  req.OpReason,
+		OpRemarks: req.OpRemarks,
+		OpUser:    req.OpUser,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuditReviewAppendResponse{AppendId: req.AppendId, Status: req.Status}, nil
+}
+
 // 根据店铺ID获取评论列表
 func (s *ReviewService) GetReviewListByStoreID(ctx context.Context, req *pb.GetReviewListByStoreIDRequest) (*pb.GetReviewListByStoreIDResponse, error) {
-	reviews, err := s.uc.GetReviewListByStoreID(ctx, req.StoreId, req.Page, req.Size)
-	if err != nil {
-		return nil, err
-	}
-	pbReviews := make([]*pb.ReviewInfo, len(reviews))
-	for i, review := range reviews {
-		pbReviews[i] = &pb.ReviewInfo{
-			ReviewId:     review.ReviewID,
-			UserId:       review.UserID,
-			Content:      review.Content,
-			PicInfo:      review.PicInfo,
-			VideoInfo:    review.VideoInfo,
-			Score:        review.Score,
-			ServiceScore: review.ServiceScore,
-			ExpressScore: review.ExpressScore,
-			Anonymous:    review.Anonymous,
+	filter := &biz.ReviewListFilter{
+		MinScore:    req.MinScore,
+		MaxScore:    req.MaxScore,
+		HasMedia:    req.HasMedia,
+		HasReply:    req.HasReply,
+		HideDefault: req.HideDefault,
+		Tags:        req.Tags,
+		SortBy:      biz.ReviewSortBy(req.SortBy),
+	}
+	var err error
+	if filter.StartTime, err = parseDateTime(req.StartTime); err != nil {
+		return nil, pb.ErrorParamErr("开始时间格式错误")
+	}
+	if filter.EndTime, err = parseDateTime(req.EndTime); err != nil {
+		return nil, pb.ErrorParamErr("结束时间格式错误")
+	}
+	result, err := s.uc.GetReviewListByStoreID(ctx, req.StoreId, filter, req.Page, req.Size, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	pbReviews := make([]*pb.ReviewInfo, len(result.List))
+	for i, review := range result.List {
+		pbReviews[i] = toPbReviewInfo(review)
+	}
+	s.fillAuthors(ctx, pbReviews)
+	return &pb.GetReviewListByStoreIDResponse{List: pbReviews, NextPageToken: result.NextPageToken, Total: result.Total}, nil
+}
+
+// 根据商品SPU获取评论列表
+func (s *ReviewService) ListReviewsBySpu(ctx context.Context, req *pb.ListReviewsBySpuRequest) (*pb.ListReviewsBySpuResponse, error) {
+	result, err := s.uc.ListReviewsBySpu(ctx, req.SpuId, req.SkuId, req.Page, req.Size, req.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	pbReviews := make([]*pb.ReviewInfo, len(result.List))
+	for i, review := range result.List {
+		pbReviews[i] = toPbReviewInfo(review)
+	}
+	s.fillAuthors(ctx, pbReviews)
+	return &pb.ListReviewsBySpuResponse{List: pbReviews, NextPageToken: result.NextPageToken, Total: result.Total}, nil
+}
+
+// 按关键词搜索评论
+func (s *ReviewService) SearchReviews(ctx context.Context, req *pb.SearchReviewsRequest) (*pb.SearchReviewsResponse, error) {
+	hits, total, err := s.uc.SearchReviews(ctx, &biz.ReviewSearchParam{
+		Keyword: req.Keyword,
+		StoreID: req.StoreId,
+		SpuID:   req.SpuId,
+	}, req.Page, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*pb.SearchReviewHit, len(hits))
+	pbReviews := make([]*pb.ReviewInfo, len(hits))
+	for i, hit := range hits {
+		pbReviews[i] = toPbReviewInfo(hit.Review)
+		list[i] = &pb.SearchReviewHit{Review: pbReviews[i], Highlights: hit.Highlights}
+	}
+	s.fillAuthors(ctx, pbReviews)
+	return &pb.SearchReviewsResponse{List: list, Total: total}, nil
+}
+
+// 获取店铺评分汇总
+func (s *ReviewService) GetStoreRatingSummary(ctx context.Context, req *pb.GetStoreRatingSummaryRequest) (*pb.GetStoreRatingSummaryResponse, error) {
+	summary, err := s.uc.GetStoreRatingSummary(ctx, req.StoreId)
+	if err != nil {
+		return nil, err
+	}
+	scoreCounts := make([]*pb.ScoreCount, len(summary.ScoreCounts))
+	for i, count := range summary.ScoreCounts {
+		scoreCounts[i] = &pb.ScoreCount{Score: int32(i + 1), Count: count}
+	}
+	return &pb.GetStoreRatingSummaryResponse{
+		StoreId:         summary.StoreID,
+		Total:           summary.Total,
+		AvgScore:        summary.AvgScore,
+		AvgServiceScore: summary.AvgServiceScore,
+		AvgExpressScore: summary.AvgExpressScore,
+		ScoreCounts:     scoreCounts,
+		MediaRate:       summary.MediaRate,
+		ReplyRate:       summary.ReplyRate,
+	}, nil
+}
+
+// 运营审核评论
+func (s *ReviewService) AuditReview(ctx context.Context, req *pb.AuditReviewRequest) (*pb.AuditReviewResponse, error) {
+	err := s.uc.AuditReview(ctx, &biz.AuditReview{
+		ReviewID:  req.ReviewId,
+		Status:    req.Status,
+		OpReason:  req.OpReason,
+		OpRemarks: req.OpRemarks,
+		OpUser:    req.OpUser,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AuditReviewResponse{ReviewId: req.ReviewId, Status: req.Status}, nil
+}
+
+// 根据评论ID获取评论详情
+func (s *ReviewService) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	detail, err := s.uc.GetReview(ctx, req.ReviewId)
+	if err != nil {
+		return nil, err
+	}
+	// 未审核通过的评论对其他调用方按不存在处理
+	if !viewerFromContext(ctx).CanSeeReview(detail.Review.Status, detail.Review.UserID) {
+		return nil, pb.ErrorGormBadErr("评论不存在")
+	}
+	review := toPbReviewDetail(detail)
+	s.fillAuthors(ctx, []*pb.ReviewInfo{review})
+	return &pb.GetReviewResponse{Review: review}, nil
+}
+
+// 批量获取评论详情
+func (s *ReviewService) BatchGetReviews(ctx context.Context, req *pb.BatchGetReviewsRequest) (*pb.BatchGetReviewsResponse, error) {
+	details, err := s.uc.BatchGetReviews(ctx, req.ReviewIds)
+	if err != nil {
+		return nil, err
+	}
+	// 未审核通过的评论对其他调用方按不存在处理，不返回
+	viewer := viewerFromContext(ctx)
+	pbReviews := make([]*pb.ReviewInfo, 0, len(details))
+	for _, detail := range details {
+		if !viewer.CanSeeReview(detail.Review.Status, detail.Review.UserID) {
+			continue
+		}
+		pbReviews = append(pbReviews, toPbReviewDetail(detail))
+	}
+	s.fillAuthors(ctx, pbReviews)
+	return &pb.BatchGetReviewsResponse{List: pbReviews}, nil
+}
+
+// 根据用户ID获取评论列表
+func (s *ReviewService) ListReviewsByUser(ctx context.Context, req *pb.ListReviewsByUserRequest) (*pb.ListReviewsByUserResponse, error) {
+	// 按用户查询会暴露匿名评论的作者，本人和运营以外的调用方不返回匿名评论，也只能看到审核通过的评论和追评
+	isOwner := viewerFromContext(ctx).CanSeeAuthor(req.UserId)
+	statuses := req.Statuses
+	if !isOwner {
+		statuses = []int32{biz.ReviewStatusApproved}
+	}
+	details, nextCursor, err := s.uc.ListReviewsByUser(ctx, &biz.ListReviewsByUserParam{
+		UserID:   req.UserId,
+		Cursor:   req.Cursor,
+		Size:     req.Size,
+		Statuses: statuses,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pbReviews := make([]*pb.ReviewInfo, 0, len(details))
+	for _, detail := range details {
+		if detail.Review.Anonymous == 1 && !isOwner {
+			continue
+		}
+		info := toPbReviewDetail(detail)
+		if info.Append != nil && info.Append.Status != biz.ReviewStatusApproved && !isOwner {
+			info.Append = nil
+		}
+		pbReviews = append(pbReviews, info)
+	}
+	s.fillAuthors(ctx, pbReviews)
+	return &pb.ListReviewsByUserResponse{List: pbReviews, NextCursor: nextCursor, HasMore: nextCursor > 0}, nil
+}
+
+// parseDateTime 解析yyyy-MM-dd HH:mm:ss格式的时间，空字符串返回零值
+func parseDateTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(time.DateTime, s, time.Local)
+}
+
+// toPbGoodsSnapshot 商品快照转换为pb结构，未保存或无法解析的快照返回nil
+func toPbGoodsSnapshot(s string) *pb.GoodsSnapshot {
+	snapshot, err := biz.ParseGoodsSnapshot(s)
+	if err != nil || snapshot == nil {
+		return nil
+	}
+	attrs := make([]*pb.SkuAttr, len(snapshot.Attrs))
+	for i, attr := range snapshot.Attrs {
+		attrs[i] = &pb.SkuAttr{Name: attr.Name, Value: attr.Value}
+	}
+	return &pb.GoodsSnapshot{
+		Version:   int32(snapshot.Version),
+		SkuId:     snapshot.SkuID,
+		SpuId:     snapshot.SpuID,
+		Title:     snapshot.Title,
+		Attrs:     attrs,
+		Image:     snapshot.Image,
+		Price:     snapshot.Price,
+		CaptureAt: snapshot.CaptureAt.Format(time.DateTime),
+	}
+}
+
+// toPbReviewInfo es中的评论转换为pb结构
+func toPbReviewInfo(review *biz.ReviewInfo) *pb.ReviewInfo {
+	info := &pb.ReviewInfo{
+		ReviewId:      review.ReviewID,
+		UserId:        review.UserID,
+		SkuId:         review.SkuID,
+		SpuId:         review.SpuID,
+		StoreId:       review.StoreID,
+		Content:       review.Content,
+		PicInfo:       review.PicInfo,
+		VideoInfo:     review.VideoInfo,
+		Score:         review.Score,
+		ServiceScore:  review.ServiceScore,
+		ExpressScore:  review.ExpressScore,
+		Anonymous:     review.Anonymous,
+		HasMedia:      review.HasMedia,
+		HasReply:      review.HasReply,
+		IsDefault:     review.IsDefault,
+		Tags:          review.Tags,
+		CreateAt:      time.Time(review.CreateAt).Format(time.DateTime),
+		GoodsSnapshot: toPbGoodsSnapshot(review.GoodsSnapshoot),
+	}
+	if a := review.Append; a != nil {
+		info.Append = &pb.ReviewAppendInfo{
+			AppendId:  a.AppendID,
+			ReviewId:  review.ReviewID,
+			Content:   a.Content,
+			PicInfo:   a.PicInfo,
+			VideoInfo: a.VideoInfo,
+			Status:    biz.ReviewStatusApproved,
+			CreateAt:  time.Time(a.CreateAt).Format(time.DateTime),
+		}
+	}
+	return info
+}
+
+// toPbReviewDetail 评论详情转换为pb结构
+func toPbReviewDetail(detail *biz.ReviewDetail) *pb.ReviewInfo {
+	review := detail.Review
+	info := &pb.ReviewInfo{
+		ReviewId:      review.ReviewID,
+		UserId:        review.UserID,
+		OrderId:       review.OrderID,
+		SkuId:         review.SkuID,
+		SpuId:         review.SpuID,
+		StoreId:       review.StoreID,
+		Content:       review.Content,
+		PicInfo:       review.PicInfo,
+		VideoInfo:     review.VideoInfo,
+		Score:         review.Score,
+		ServiceScore:  review.ServiceScore,
+		ExpressScore:  review.ExpressScore,
+		Anonymous:     review.Anonymous,
+		HasMedia:      review.HasMedia,
+		HasReply:      review.HasReply,
+		IsDefault:     review.IsDefault,
+		Status:        review.Status,
+		Tags:          review.Tags,
+		CreateAt:      review.CreateAt.Format(time.DateTime),
+		Version:       review.Version,
+		GoodsSnapshot: toPbGoodsSnapshot(review.GoodsSnapshoot),
+	}
+	if reply := detail.Reply; reply != nil {
+		info.Reply = &pb.ReviewReplyInfo{
+			ReplyId:   reply.ReplyID,
+			ReviewId:  reply.ReviewID,
+			StoreId:   reply.StoreID,
+			Content:   reply.Content,
+			PicInfo:   reply.PicInfo,
+			VideoInfo: reply.VideoInfo,
+			CreateAt:  reply.CreateAt.Format(time.DateTime),
+		}
+	}
+	if a := detail.Append; a != nil {
+		info.Append = &pb.ReviewAppendInfo{
+			AppendId:  a.AppendID,
+			ReviewId:  a.ReviewID,
+			Content:   a.Content,
+			PicInfo:   a.PicInfo,
+			VideoInfo: a.VideoInfo,
+			Status:    a.Status,
+			CreateAt:  a.CreateAt.Format(time.DateTime),
+		}
+	}
+	if appeal := detail.Appeal; appeal != nil {
+		info.Appeal = &pb.ReviewAppealInfo{
+			AppealId:  appeal.AppealID,
+			ReviewId:  appeal.ReviewID,
+			StoreId:   appeal.StoreID,
+			Status:    appeal.Status,
+			Reason:    appeal.Reason,
+			Content:   appeal.Content,
+			PicInfo:   appeal.PicInfo,
+			VideoInfo: appeal.VideoInfo,
+			OpRemarks: appeal.OpRemarks,
+			CreateAt:  appeal.CreateAt.Format(time.DateTime),
 		}
 	}
-	return &pb.GetReviewListByStoreIDResponse{List: pbReviews}, nil
+	return info
 }
@@ -0,0 +1,8 @@
+-- 每个订单商品行只允许一条评论，BatchSaveReviews依赖该唯一索引兜底并发重复提交
+-- （用户CreateReview/BatchCreateReviews与默认评价任务同时写入同一商品行）。
+-- 评论为软删除，已删除的评论仍占用该商品行，删除后不能再次评论同一商品行。
+--
+-- 上线前先确认没有重复数据，有结果时需先人工处理：
+--   SELECT order_id, sku_id, COUNT(*) FROM review_info GROUP BY order_id, sku_id HAVING COUNT(*) > 1;
+
+ALTER TABLE review_info ADD UNIQUE KEY uk_order_sku (order_id, sku_id);
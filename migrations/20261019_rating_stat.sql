@@ -0,0 +1,50 @@
+-- 店铺、商品评分统计表，只统计审核通过的评价。
+-- applyRatingStat使用INSERT ... ON DUPLICATE KEY UPDATE累加增量，依赖store_id、spu_id上的唯一索引，
+-- 缺少唯一索引时每次增量都会插入新行。
+
+CREATE TABLE IF NOT EXISTS store_rating_stat (
+    id                BIGINT   NOT NULL AUTO_INCREMENT COMMENT '主键',
+    create_at         DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    update_at         DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    store_id          BIGINT   NOT NULL COMMENT '店铺id',
+    review_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '评价数',
+    score_sum         BIGINT   NOT NULL DEFAULT 0 COMMENT '评分总和',
+    service_score_sum BIGINT   NOT NULL DEFAULT 0 COMMENT '商家服务评分总和',
+    express_score_sum BIGINT   NOT NULL DEFAULT 0 COMMENT '物流评分总和',
+    score1_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '1星评价数',
+    score2_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '2星评价数',
+    score3_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '3星评价数',
+    score4_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '4星评价数',
+    score5_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '5星评价数',
+    media_count       BIGINT   NOT NULL DEFAULT 0 COMMENT '有图或视频的评价数',
+    reply_count       BIGINT   NOT NULL DEFAULT 0 COMMENT '商家已回复的评价数',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_store_id (store_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '店铺评分统计表，只统计审核通过的评价';
+
+CREATE TABLE IF NOT EXISTS spu_rating_stat (
+    id                BIGINT   NOT NULL AUTO_INCREMENT COMMENT '主键',
+    create_at         DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    update_at         DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    spu_id            BIGINT   NOT NULL COMMENT 'spu id',
+    review_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '评价数',
+    score_sum         BIGINT   NOT NULL DEFAULT 0 COMMENT '评分总和',
+    service_score_sum BIGINT   NOT NULL DEFAULT 0 COMMENT '商家服务评分总和',
+    express_score_sum BIGINT   NOT NULL DEFAULT 0 COMMENT '物流评分总和',
+    score1_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '1星评价数',
+    score2_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '2星评价数',
+    score3_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '3星评价数',
+    score4_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '4星评价数',
+    score5_count      BIGINT   NOT NULL DEFAULT 0 COMMENT '5星评价数',
+    media_count       BIGINT   NOT NULL DEFAULT 0 COMMENT '有图或视频的评价数',
+    reply_count       BIGINT   NOT NULL DEFAULT 0 COMMENT '商家已回复的评价数',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_spu_id (spu_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '商品评分统计表，只统计审核通过的评价';
+
+-- 表已存在但缺少唯一索引时，统计已按多行分散无法直接合并，清空后补唯一索引，
+-- 再执行 go run ./cmd/ratingstat -fix 按review_info重算（重算同样依赖唯一索引）：
+--   TRUNCATE TABLE store_rating_stat;
+--   TRUNCATE TABLE spu_rating_stat;
+--   ALTER TABLE store_rating_stat ADD UNIQUE KEY uk_store_id (store_id);
+--   ALTER TABLE spu_rating_stat ADD UNIQUE KEY uk_spu_id (spu_id);
@@ -0,0 +1,27 @@
+-- 追评表，每条评论只能追评一次，追评单独审核。
+-- uk_review_id兜底并发重复追评，冲突时返回“评论已追评”；追评为软删除，已删除的追评仍占用该评论。
+
+CREATE TABLE IF NOT EXISTS review_append_info (
+    id         BIGINT        NOT NULL AUTO_INCREMENT COMMENT '主键',
+    create_by  VARCHAR(48)   NOT NULL DEFAULT '' COMMENT '创建⽅标识',
+    update_by  VARCHAR(48)   NOT NULL DEFAULT '' COMMENT '更新⽅标识',
+    create_at  DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    update_at  DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    delete_at  DATETIME               DEFAULT NULL COMMENT '逻辑删除标记',
+    version    INT           NOT NULL DEFAULT 0 COMMENT '乐观锁标记',
+    append_id  BIGINT        NOT NULL COMMENT '追评id',
+    review_id  BIGINT        NOT NULL COMMENT '评价id',
+    user_id    BIGINT        NOT NULL COMMENT '⽤户id',
+    content    VARCHAR(512)  NOT NULL COMMENT '追评内容',
+    pic_info   VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：图⽚',
+    video_info VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '媒体信息：视频',
+    status     TINYINT       NOT NULL DEFAULT 10 COMMENT '状态:10待审核；20审核通过；30审核不通过；40隐藏',
+    op_reason  VARCHAR(512)  NOT NULL DEFAULT '' COMMENT '运营审核拒绝原因',
+    op_remarks VARCHAR(512)  NOT NULL DEFAULT '' COMMENT '运营备注',
+    op_user    VARCHAR(64)   NOT NULL DEFAULT '' COMMENT '运营者标识',
+    ext_json   VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '信息扩展',
+    ctrl_json  VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '控制扩展',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_append_id (append_id),
+    UNIQUE KEY uk_review_id (review_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '评价追评表';
@@ -0,0 +1,17 @@
+-- 评论ES同步事件表，与评论变更在同一事务中写入，由ReviewIndexer消费。
+-- 事件ID自增，同时作为ES文档的external_gte版本号。
+-- idx_status_next_retry用于ListPendingIndexEvents按状态、到期时间拉取待同步事件。
+
+CREATE TABLE IF NOT EXISTS review_es_outbox (
+    id            BIGINT        NOT NULL AUTO_INCREMENT COMMENT '主键',
+    create_at     DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    update_at     DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    review_id     BIGINT        NOT NULL COMMENT '评价id',
+    status        TINYINT       NOT NULL DEFAULT 10 COMMENT '状态:10待同步；20已同步；30同步失败',
+    retry_count   INT           NOT NULL DEFAULT 0 COMMENT '重试次数',
+    next_retry_at DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '下次重试时间',
+    last_error    VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '最近一次失败原因',
+    PRIMARY KEY (id),
+    KEY idx_status_next_retry (status, next_retry_at, id),
+    KEY idx_review_id (review_id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COMMENT = '评论ES同步事件表';
@@ -0,0 +1,5 @@
+-- 创建评论时未计算has_media，修复历史数据。
+-- 执行后需运行 go run ./cmd/ratingstat -fix 重算评分统计中的有图评论数，
+-- 并执行 go run ./cmd/esindex -action=reindex 重建ES索引。
+
+UPDATE review_info SET has_media = 1 WHERE has_media = 0 AND (pic_info <> '' OR video_info <> '');